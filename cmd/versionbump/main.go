@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/ptgoetz/go-versionbump/internal"
 	vbc "github.com/ptgoetz/go-versionbump/internal/config"
@@ -12,6 +15,7 @@ import (
 )
 
 func main() {
+	addConfigStrategyCommands(os.Args[1:])
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
@@ -19,6 +23,19 @@ func main() {
 
 var opts vbc.Options
 
+// bumpFlags are the flags of the bump commands
+var bumpFlags = pflag.NewFlagSet("bump", pflag.ExitOnError)
+
+// strategyNotes are added to the help of the commands of built-in strategies
+var strategyNotes = map[string]string{
+	semver.PreRelease.String(): `With --channel, bump on a named pre-release channel instead. Each channel is numbered independently: bumping within
+the same channel increments its number (e.g. 1.2.3-beta.1 -> 1.2.3-beta.2), and switching channels starts at 1
+(e.g. 1.2.3-nightly.5 -> 1.2.3-beta.1).`,
+	semver.PreReleaseBuild.String(): `When build-metadata providers are configured, or given with --build-meta, the build metadata is generated from them
+instead (e.g. 1.2.3 -> 1.2.3+sha.1a2b3c4.20241016).`,
+	semver.Revision.String(): `Requires version-scheme: four-part.`,
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "versionbump",
 	Short: `VersionBump is a command-line tool designed to automate version string management in projects.`,
@@ -26,25 +43,19 @@ var rootCmd = &cobra.Command{
 	RunE:  runRootCmd, // Use RunE for better error handling
 }
 
-var majorCmd = &cobra.Command{
-	Use:   semver.Major.String(),
-	Short: `Bump the major version number (e.g. 1.2.3 -> 2.0.0).`,
-	Long:  `Bump the major version number (e.g. 1.2.3 -> 2.0.0).`,
-	RunE:  bumpMajor, // Use RunE for better error handling
-}
-
-var minorCmd = &cobra.Command{
-	Use:   semver.Minor.String(),
-	Short: `Bump the minor version number (e.g. 1.2.3 -> 1.3.0).`,
-	Long:  `Bump the minor version number (e.g. 1.2.3 -> 1.3.0).`,
-	RunE:  bumpMinor, // Use RunE for better error handling
-}
-
-var patchCmd = &cobra.Command{
-	Use:   semver.Patch.String(),
-	Short: `Bump the patch version number (e.g. 1.2.3 -> 1.2.4).`,
-	Long:  `Bump the patch version number (e.g. 1.2.3 -> 1.2.4).`,
-	RunE:  bumpPatch, // Use RunE for better error handling
+var bumpCmd = &cobra.Command{
+	Use:   "bump <strategy>[,<strategy>...]",
+	Short: `Bump the version with one or more strategies, applied in order (e.g. minor,pre).`,
+	Long: `Bump the version with one or more comma-separated strategies, applied in order (e.g. bump minor,pre:
+1.2.3 -> 1.3.0 -> 1.3.0-alpha). The chain is validated before any files are changed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := semver.SplitStrategyChain(args[0])
+		if err != nil {
+			return err
+		}
+		return runVersionBump(semver.BumpStrategy(strings.Join(names, semver.StrategyChainSeparator)))
+	},
 }
 
 var configCmd = &cobra.Command{
@@ -54,6 +65,36 @@ var configCmd = &cobra.Command{
 	RunE:  runConfigCmd, // Use RunE for better error handling
 }
 
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: `Set a value in the configuration file.`,
+	Long: `Set a single-valued setting (e.g. git-sign) in the configuration file. Comments and formatting are preserved,
+and the file is left unchanged if the resulting configuration is invalid.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return internal.SetConfigValue(opts, args[0], args[1])
+	},
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: `Validate the configuration file and report any problems.`,
+	Long:  `Validate the configuration file, and the files it extends, and report every problem with its line and column.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return internal.ValidateConfig(opts)
+	},
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: `Print the JSON Schema of the configuration file.`,
+	Long:  `Print the JSON Schema of the configuration file, for editor validation and autocompletion.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return internal.ShowSchema()
+	},
+}
+
 var resetCmd = &cobra.Command{
 	Use:   "set <version>",
 	Short: `Set the project version to the specified value.`,
@@ -72,8 +113,10 @@ var initCmd = &cobra.Command{
 var showCmd = &cobra.Command{
 	Use:   "show [version]",
 	Short: `Show potential versioning paths for the project version or a specific version.`,
-	Long:  `Show potential versioning paths for the project version or a specific version.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Show potential versioning paths for the project version or a specific version, as a text tree, JSON, a Mermaid
+flowchart or a Graphviz DOT graph. With --depth 2, each path is expanded with a second bump (e.g. 1.2.3 -> new-pre-minor
+-> 1.3.0-alpha -> pre -> 1.3.0-beta).`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		vb, err := internal.NewVersionBump(opts)
 		if err != nil {
@@ -115,146 +158,172 @@ var showLatestCmd = &cobra.Command{
 	},
 }
 
-var gitTagHistoryCmd = &cobra.Command{
-	Use:   "history",
-	Short: `Show the sorted version history based on git tags.`,
-	Long:  `Show the sorted version history based on git tags.`,
+var describeCmd = &cobra.Command{
+	Use:   "describe [commit]",
+	Short: `Show a development version for a commit based on git tags (e.g. 1.4.1-dev.7+g1a2b3c4).`,
+	Long: `Show a development version for a commit (default: HEAD) based on the latest version tag reachable from it,
+in the style of git describe (e.g. 1.4.1-dev.7+g1a2b3c4). The version of a tagged commit is shown as is. No files are
+modified.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		vb, err := internal.NewVersionBump(opts)
 		if err != nil {
 			return err
 		}
-		err = vb.GitTagHistory()
-		return err
+		commit := ""
+		if len(args) > 0 {
+			commit = args[0]
+		}
+		version, err := vb.Describe(commit)
+		if err != nil {
+			return err
+		}
+		fmt.Println(version)
+		return nil
 	},
 }
 
-var preReleaseNextCmd = &cobra.Command{
-	Use:   semver.PreRelease.String(),
-	Short: `Bump the next pre-release version label (e.g. 1.2.3-alpha -> 1.2.3-beta).`,
-	Long:  `Bump the next pre-release version label (e.g. 1.2.3-alpha -> 1.2.3-beta).`,
-	RunE:  bumpPreReleaseNext, // Use RunE for better error handling
-}
-
-var preReleaseMajorCmd = &cobra.Command{
-	Use:   semver.PreReleaseMajor.String(),
-	Short: `Bump the pre-release major version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.1).`,
-	Long:  `Bump the pre-release major version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.1).`,
-	RunE:  bumpPreReleaseMajor, // Use RunE for better error handling
-}
-
-var preReleaseMinorCmd = &cobra.Command{
-	Use:   semver.PreReleaseMinor.String(),
-	Short: `Bump the pre-release minor version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.0.1).`,
-	Long:  `Bump the pre-release minor version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.0.1).`,
-	RunE:  bumpPreReleaseMinor, // Use RunE for better error handling
-}
-
-var preReleasePatchCmd = &cobra.Command{
-	Use:   semver.PreReleasePatch.String(),
-	Short: `Bump the pre-release patch version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.0.0.1).`,
-	Long:  `Bump the pre-release patch version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.0.0.1).`,
-	RunE:  bumpPreReleasePatch, // Use RunE for better error handling
-}
-
-var preReleaseNewMajorCmd = &cobra.Command{
-	Use:   semver.PreReleaseNewMajor.String(),
-	Short: `Bump the major version and apply the first pre-release label (e.g. 1.2.3 -> 2.0.0-alpha).`,
-	Long:  `Bump the major version and apply the first pre-release label (e.g. 1.2.3 -> 2.0.0-alpha).`,
-	RunE:  bumpNewPreReleaseMajor, // Use RunE for better error handling
-}
-
-var preReleaseNewMinorCmd = &cobra.Command{
-	Use:   semver.PreReleaseNewMinor.String(),
-	Short: `Bump the minor version and apply the first pre-release label (e.g. 1.2.3 -> 1.3.0-alpha).`,
-	Long:  `Bump the minor version and apply the first pre-release label (e.g. 1.2.3 -> 1.3.0-alpha).`,
-	RunE:  bumpNewPreReleaseMinor, // Use RunE for better error handling
-}
-
-var preReleaseNewPatchCmd = &cobra.Command{
-	Use:   semver.PreReleaseNewPatch.String(),
-	Short: `Bump the patch version and apply the first pre-release label (e.g. 1.2.3 -> 1.2.4-alpha).`,
-	Long:  `Bump the patch version and apply the first pre-release label (e.g. 1.2.3 -> 1.2.4-alpha).`,
-	RunE:  bumpNewPreReleasePatch, // Use RunE for better error handling
+var diffCmd = &cobra.Command{
+	Use:   "diff <from> <to>",
+	Short: `Show which part of the version changes between two versions (e.g. 1.2.3 2.0.0-rc.1).`,
+	Long: `Show which part of the version changes between two versions (major, minor, patch, revision, prerelease or
+build), the direction of the change, and whether it crosses a stability boundary (0.x -> 1.x, or pre-release ->
+release). Unusual transitions, such as versions that move backwards, are reported as warnings. The configuration file
+is optional: it orders the pre-release labels and sets the version scheme.`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return internal.DiffVersions(opts, args[0], args[1])
+	},
 }
 
-var releaseCmd = &cobra.Command{
-	Use:   semver.Release.String(),
-	Short: `Bump the pre-release version to a release version (e.g. 1.2.3-alpha -> 1.2.3).`,
-	Long:  `Bump the pre-release version to a release version (e.g. 1.2.3-alpha -> 1.2.3).`,
-	RunE:  bumpRelease, // Use RunE for better error handling
+var nextCmd = &cobra.Command{
+	Use:   "next <strategy>[,<strategy>...]",
+	Short: `Print the version a strategy would bump to, without changing anything (e.g. next minor).`,
+	Long: `Print the version a strategy, or a chain of comma-separated strategies, would bump the project version to. No
+files are changed and git isn't run, so it's safe in read-only checkouts. Use --from to bump another version (the
+configuration file is optional then), --format to print the version in a dialect (e.g. pep440), or --tag to print
+the git tag name instead. Build metadata providers are not applied.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, err := internal.NextVersion(opts, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(version)
+		return nil
+	},
 }
 
-var preReleaseBuildCmd = &cobra.Command{
-	Use:   semver.PreReleaseBuild.String(),
-	Short: `Bump the pre-release build version number (e.g. 1.2.3 -> 1.2.3+build.1).`,
-	Long:  `Bump the pre-release build version number (e.g. 1.2.3 -> 1.2.3+build.1).`,
-	RunE:  bumpPreReleaseBuild, // Use RunE for better error handling
+var gitTagHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: `Show the sorted version history based on git tags.`,
+	Long:  `Show the sorted version history based on git tags.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vb, err := internal.NewVersionBump(opts)
+		if err != nil {
+			return err
+		}
+		err = vb.GitTagHistory()
+		return err
+	},
 }
 
 func init() {
 	rootCmd.Flags().BoolVarP(&opts.ShowVersion, "version", "V", false, "Show the VersionBump version and exit.")
 
 	commonFlags := pflag.NewFlagSet("common", pflag.ExitOnError)
-	commonFlags.StringVarP(&opts.ConfigPath, "config", "c", "versionbump.yaml", "The path to the configuration file")
+	commonFlags.StringVarP(&opts.ConfigPath, "config", "c", "", "The path to the configuration file (default: search for versionbump.yaml from the current directory upwards)")
 	commonFlags.BoolVar(&opts.NoPrompt, "no-prompt", false, "Don't prompt the user for confirmation before making changes.")
 	commonFlags.BoolVarP(&opts.Quiet, "quiet", "q", false, "Don't print verbose output.")
 	commonFlags.BoolVar(&opts.NoGit, "no-git", false, "Don't perform any git operations.")
 	commonFlags.BoolVar(&opts.NoColor, "no-color", false, "Disable color output.")
 
 	configColorFlags := pflag.NewFlagSet("config-color", pflag.ExitOnError)
-	configColorFlags.StringVarP(&opts.ConfigPath, "config", "c", "versionbump.yaml", "The path to the configuration file")
+	configColorFlags.StringVarP(&opts.ConfigPath, "config", "c", "", "The path to the configuration file (default: search for versionbump.yaml from the current directory upwards)")
 	configColorFlags.BoolVar(&opts.NoColor, "no-color", false, "Disable color output.")
 
 	commonFlags.AddFlagSet(configColorFlags)
 
 	initFlags := pflag.NewFlagSet("init", pflag.ExitOnError)
-	initFlags.StringVarP(&opts.InitOpts.File, "file", "f", "versionbump.yaml", "The name of the configuration file to create.")
+	initFlags.StringVarP(&opts.InitOpts.File, "file", "f", "", "The name of the configuration file to create (default: versionbump.<format>).")
+	initFlags.StringVar(&opts.InitOpts.Format, "format", "", "The configuration file format: yaml, json or toml (default: detected from --file, or yaml).")
 	initFlags.BoolVar(&opts.InitOpts.NoInteractive, "no-interactive", false, "Don't prompt for interactive input.")
+	initFlags.StringVar(&opts.InitOpts.InitialVersion, "initial-version", vbc.DefaultVersion, "The initial version of the project.")
+	initFlags.StringSliceVar(&opts.InitOpts.PreReleaseLabels, "prerelease-labels", vbc.DetaultPreReleaseLabels, "The pre-release labels (comma-separated).")
+	initFlags.StringVar(&opts.InitOpts.BuildLabel, "build-label", vbc.DefaultBuildLabel, "The build label.")
+	initFlags.BoolVar(&opts.InitOpts.GitCommit, "git-commit", false, "Enable git commits.")
+	initFlags.BoolVar(&opts.InitOpts.GitTag, "git-tag", false, "Enable git tags.")
+	initFlags.BoolVar(&opts.InitOpts.GitSign, "git-sign", false, "Enable signing git commits and tags.")
+	initFlags.BoolVar(&opts.InitOpts.ScanDirectory, "scan", false, "Scan the project for files containing the initial version and propose tracked files.")
 	initCmd.Flags().AddFlagSet(initFlags)
 
-	prereleaserFlags := pflag.NewFlagSet("prelease", pflag.ExitOnError)
-	prereleaserFlags.AddFlagSet(commonFlags)
-
-	preReleaseNextCmd.Flags().AddFlagSet(prereleaserFlags)
-	preReleaseMajorCmd.Flags().AddFlagSet(prereleaserFlags)
-	preReleaseMinorCmd.Flags().AddFlagSet(prereleaserFlags)
-	preReleasePatchCmd.Flags().AddFlagSet(prereleaserFlags)
-	preReleaseBuildCmd.Flags().AddFlagSet(prereleaserFlags)
-	preReleaseNewMajorCmd.Flags().AddFlagSet(prereleaserFlags)
-	preReleaseNewMinorCmd.Flags().AddFlagSet(prereleaserFlags)
-	preReleaseNewPatchCmd.Flags().AddFlagSet(prereleaserFlags)
-	releaseCmd.Flags().AddFlagSet(prereleaserFlags)
+	bumpFlags.AddFlagSet(commonFlags)
+	bumpFlags.StringSliceVar(&opts.BuildMetadata, "build-meta", nil, "Build metadata providers to append to the new version (comma-separated): sha, timestamp, date, commits, dirty, ci or env:NAME.")
+
+	forceFlags := pflag.NewFlagSet("force", pflag.ExitOnError)
+	forceFlags.BoolVar(&opts.Force, "force", false, "Allow versions that the version-policy refuses, e.g. versions lower than the latest tagged version.")
+	bumpFlags.AddFlagSet(forceFlags)
+
+	// each registered strategy is a command of its own
+	strategyNames := make([]string, 0)
+	for _, strategy := range semver.Strategies() {
+		strategyNames = append(strategyNames, strategy.Name())
+		strategyCmd := strategyCommand(strategy)
+		if isBuildStrategy(strategy) {
+			strategyCmd.Flags().AddFlagSet(bumpFlags)
+		} else {
+			strategyCmd.Flags().AddFlagSet(commonFlags)
+			strategyCmd.Flags().AddFlagSet(forceFlags)
+		}
+		if strategy.Name() == semver.PreRelease.String() {
+			strategyCmd.Flags().StringVar(&opts.Channel, "channel", "", "Bump on the named pre-release channel (see prerelease-channels).")
+		}
+		rootCmd.AddCommand(strategyCmd)
+	}
 
 	showCmd.Flags().AddFlagSet(configColorFlags)
+	showCmd.Flags().StringVar(&opts.ShowOpts.Format, "format", internal.ShowFormatText, "The output format: text, json, mermaid or dot.")
+	showCmd.Flags().IntVar(&opts.ShowOpts.Depth, "depth", 1, fmt.Sprintf("The number of bumps each versioning path is expanded to, from 1 to %d.", internal.MaxShowDepth))
 	showVersionCmd.Flags().AddFlagSet(commonFlags)
 	showLatestCmd.Flags().AddFlagSet(commonFlags)
 	configCmd.Flags().AddFlagSet(configColorFlags)
-
-	majorCmd.Flags().AddFlagSet(commonFlags)
-	minorCmd.Flags().AddFlagSet(commonFlags)
-	patchCmd.Flags().AddFlagSet(commonFlags)
+	configCmd.Flags().StringVar(&opts.Format, "format", "yaml", "The output format: yaml, json or toml.")
+	configSetCmd.Flags().AddFlagSet(configColorFlags)
+	configSetCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Don't print verbose output.")
+	configCmd.AddCommand(configSetCmd)
+	validateCmd.Flags().AddFlagSet(configColorFlags)
+	describeCmd.Flags().AddFlagSet(configColorFlags)
+	diffCmd.Flags().AddFlagSet(configColorFlags)
+	nextCmd.Flags().StringVarP(&opts.ConfigPath, "config", "c", "", "The path to the configuration file (default: search for versionbump.yaml from the current directory upwards)")
+	nextCmd.Flags().StringVar(&opts.NextOpts.From, "from", "", "The version to bump (default: the project version).")
+	nextCmd.Flags().StringVar(&opts.NextOpts.Format, "format", "", "The version dialect to print: semver, pep440, npm, maven or nuget (default: semver).")
+	nextCmd.Flags().BoolVar(&opts.NextOpts.Tag, "tag", false, "Print the git tag name of the version (see git-tag-template).")
+	nextCmd.MarkFlagsMutuallyExclusive("format", "tag")
+	describeCmd.Flags().StringVar(&opts.DescribeTemplate, "template", "", "The template for the version (default: describe-template).")
+	validateCmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Don't print verbose output.")
+
+	bumpCmd.Long += fmt.Sprintf("\n\nStrategies: %s, and the strategies defined in the configuration file.",
+		strings.Join(strategyNames, ", "))
+	bumpCmd.Flags().AddFlagSet(bumpFlags)
 	resetCmd.Flags().AddFlagSet(commonFlags)
+	resetCmd.Flags().AddFlagSet(forceFlags)
 
-	rootCmd.AddCommand(majorCmd)
-	rootCmd.AddCommand(minorCmd)
-	rootCmd.AddCommand(patchCmd)
+	rootCmd.AddCommand(bumpCmd)
 	rootCmd.AddCommand(resetCmd)
-	rootCmd.AddCommand(preReleaseNextCmd)
-	rootCmd.AddCommand(preReleaseMajorCmd)
-	rootCmd.AddCommand(preReleaseMinorCmd)
-	rootCmd.AddCommand(preReleasePatchCmd)
-	rootCmd.AddCommand(preReleaseBuildCmd)
-	rootCmd.AddCommand(preReleaseNewMajorCmd)
-	rootCmd.AddCommand(preReleaseNewMinorCmd)
-	rootCmd.AddCommand(preReleaseNewPatchCmd)
-	rootCmd.AddCommand(releaseCmd)
 	rootCmd.AddCommand(showCmd)
 	rootCmd.AddCommand(showVersionCmd)
 	rootCmd.AddCommand(showLatestCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(gitTagHistoryCmd)
+	rootCmd.AddCommand(describeCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(nextCmd)
 }
 
 func runRootCmd(cmd *cobra.Command, args []string) error {
@@ -265,52 +334,62 @@ func runRootCmd(cmd *cobra.Command, args []string) error {
 	return cmd.Help()
 }
 
-func bumpMajor(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.Major)
-}
-
-func bumpMinor(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.Minor)
-}
-
-func bumpPatch(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.Patch)
-}
-
-func bumpPreReleaseNext(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.PreRelease)
-}
-
-func bumpPreReleaseMajor(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.PreReleaseMajor)
-}
-
-func bumpPreReleaseMinor(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.PreReleaseMinor)
-}
-
-func bumpPreReleasePatch(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.PreReleasePatch)
-}
-
-func bumpPreReleaseBuild(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.PreReleaseBuild)
-}
-
-func bumpNewPreReleaseMajor(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.PreReleaseNewMajor)
+// strategyCommand returns the command bumping the version with a strategy
+func strategyCommand(strategy semver.Strategy) *cobra.Command {
+	long := strategy.Describe()
+	if note, ok := strategyNotes[strategy.Name()]; ok {
+		long += "\n\n" + note
+	}
+	return &cobra.Command{
+		Use:   strategy.Name(),
+		Short: strategy.Describe(),
+		Long:  long,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVersionBump(semver.BumpStrategy(strategy.Name()))
+		},
+	}
 }
 
-func bumpNewPreReleaseMinor(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.PreReleaseNewMinor)
+// isBuildStrategy returns true if the strategy applies to semantic versions, which can have build metadata
+func isBuildStrategy(strategy semver.Strategy) bool {
+	s, ok := strategy.(semver.SchemeStrategy)
+	return !ok || len(s.Schemes()) == 0 || slices.Contains(s.Schemes(), semver.SchemeSemVer)
 }
 
-func bumpNewPreReleasePatch(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.PreReleaseNewPatch)
-}
+// addConfigStrategyCommands adds the commands of the strategies defined in the configuration file. The configuration
+// file is located before the command line is parsed, so any problem with it is left to be reported by the command.
+func addConfigStrategyCommands(args []string) {
+	configFlags := pflag.NewFlagSet("config", pflag.ContinueOnError)
+	configFlags.ParseErrorsWhitelist.UnknownFlags = true
+	configFlags.SetOutput(io.Discard)
+	configFlags.Usage = func() {}
+	configPath := configFlags.StringP("config", "c", "", "")
+	_ = configFlags.Parse(args)
 
-func bumpRelease(cmd *cobra.Command, args []string) error {
-	return runVersionBump(semver.Release)
+	path := *configPath
+	if path == "" {
+		var err error
+		if path, err = vbc.FindConfig("."); err != nil {
+			return
+		}
+	}
+	config, _, err := vbc.LoadConfig(path)
+	if err != nil {
+		return
+	}
+	strategies, err := config.GetStrategies()
+	if err != nil {
+		return
+	}
+	for _, strategy := range strategies {
+		if cmd, _, err := rootCmd.Find([]string{strategy.Name()}); err == nil && cmd != rootCmd {
+			// built-in commands take precedence
+			continue
+		}
+		strategyCmd := strategyCommand(strategy)
+		strategyCmd.Flags().AddFlagSet(bumpFlags)
+		rootCmd.AddCommand(strategyCmd)
+	}
 }
 
 func runResetCmd(cmd *cobra.Command, args []string) error {
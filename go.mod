@@ -3,6 +3,7 @@ module github.com/ptgoetz/go-versionbump
 go 1.22.2
 
 require (
+	github.com/pelletier/go-toml/v2 v2.2.3
 	github.com/spf13/cobra v1.8.1
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.9.0
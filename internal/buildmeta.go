@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/ptgoetz/go-versionbump/internal/git"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// ciBuildNumberVars are the environment variables common CI services provide the build number in, in the order they
+// are checked by the `ci` build metadata provider.
+var ciBuildNumberVars = []string{
+	"BUILD_NUMBER",           // Jenkins, TeamCity
+	"GITHUB_RUN_NUMBER",      // GitHub Actions
+	"CI_PIPELINE_IID",        // GitLab CI
+	"CIRCLE_BUILD_NUM",       // CircleCI
+	"BUILDKITE_BUILD_NUMBER", // Buildkite
+	"TRAVIS_BUILD_NUMBER",    // Travis CI
+	"BUILD_BUILDID",          // Azure Pipelines
+}
+
+// buildMetadataProviders returns the build metadata providers for the bump. Providers given on the command line apply
+// to every bump strategy, while the configured providers replace the build label and number of `pre-build`, including
+// a chain ending with it (e.g. `release,pre-build`).
+func (vb *VersionBump) buildMetadataProviders() []string {
+	if len(vb.Options.BuildMetadata) > 0 {
+		return vb.Options.BuildMetadata
+	}
+	steps := strings.Split(vb.Options.BumpPart.String(), semver.StrategyChainSeparator)
+	if steps[len(steps)-1] == semver.PreReleaseBuild.String() && vb.Options.Channel == "" {
+		return vb.Config.BuildMetadata
+	}
+	return nil
+}
+
+// allowsPendingChanges returns true if the bump uses the `dirty` build metadata provider, which marks versions built
+// from a working tree with uncommitted changes, so the changes must not stop the bump.
+func (vb *VersionBump) allowsPendingChanges() bool {
+	return slices.Contains(vb.buildMetadataProviders(), "dirty")
+}
+
+// buildMetadata returns the build metadata of the new version, or nil if there are no build metadata providers or
+// none of them provided a value. The metadata is resolved once, so the new version stays the same while the bump is
+// performed (e.g. `dirty` after the tracked files are changed).
+func (vb *VersionBump) buildMetadata() (*semver.BuildVersion, error) {
+	if !vb.buildMetadataResolved {
+		identifiers, err := vb.resolveBuildMetadata(vb.buildMetadataProviders(), time.Now())
+		if err != nil {
+			return nil, err
+		}
+		vb.buildMetadataIdentifiers = identifiers
+		vb.buildMetadataResolved = true
+	}
+	if len(vb.buildMetadataIdentifiers) == 0 {
+		return nil, nil
+	}
+	return semver.NewBuildMetadata(vb.buildMetadataIdentifiers...)
+}
+
+// resolveBuildMetadata returns the build metadata identifiers of the given providers, in order.
+func (vb *VersionBump) resolveBuildMetadata(providers []string, now time.Time) ([]string, error) {
+	identifiers := make([]string, 0)
+	for _, provider := range providers {
+		if !config.IsBuildMetadataProvider(provider) {
+			return nil, fmt.Errorf("invalid build metadata provider, expected one of %s or env:NAME: %s",
+				strings.Join(config.BuildMetadataProviders, ", "), provider)
+		}
+		switch provider {
+		case "timestamp":
+			identifiers = append(identifiers, now.UTC().Format("20060102150405"))
+			continue
+		case "date":
+			identifiers = append(identifiers, now.UTC().Format("20060102"))
+			continue
+		case "ci":
+			value, name := "", ""
+			for _, name = range ciBuildNumberVars {
+				if value = os.Getenv(name); value != "" {
+					break
+				}
+			}
+			if value == "" {
+				return nil, fmt.Errorf("build metadata provider ci: no CI build number found in %s",
+					strings.Join(ciBuildNumberVars, ", "))
+			}
+			identifiers = append(identifiers, "ci")
+			identifiers = append(identifiers, buildIdentifiers(value)...)
+			continue
+		}
+		if name, ok := strings.CutPrefix(provider, "env:"); ok {
+			value := buildIdentifiers(os.Getenv(name))
+			if len(value) == 0 {
+				return nil, fmt.Errorf("build metadata provider %s: environment variable %s is not set", provider, name)
+			}
+			identifiers = append(identifiers, value...)
+			continue
+		}
+
+		// the remaining providers read the git repository, so they aren't available when git is disabled
+		if vb.Options.NoGit {
+			return nil, fmt.Errorf("build metadata provider %s requires git, which is disabled with --no-git", provider)
+		}
+		if isRepo, _ := git.IsRepository(vb.ParentDir); !isRepo {
+			return nil, fmt.Errorf("build metadata provider %s requires a git repository", provider)
+		}
+		switch provider {
+		case "sha":
+			sha, err := git.GetCommitSHA(vb.ParentDir, "HEAD")
+			if err != nil {
+				return nil, err
+			}
+			identifiers = append(identifiers, "sha", sha)
+		case "commits":
+			tag, _, err := vb.versionTag("HEAD")
+			if err != nil {
+				return nil, err
+			}
+			count, err := git.CountCommits(vb.ParentDir, tag, "HEAD")
+			if err != nil {
+				return nil, err
+			}
+			identifiers = append(identifiers, "commits", strconv.Itoa(count))
+		case "dirty":
+			dirty, err := git.HasPendingChanges(vb.ParentDir)
+			if err != nil {
+				return nil, err
+			}
+			if dirty {
+				identifiers = append(identifiers, "dirty")
+			}
+		}
+	}
+	return identifiers, nil
+}
+
+// buildIdentifiers converts a value (e.g. a CI build number) to build metadata identifiers. Characters that aren't
+// allowed in build metadata are replaced with `-`.
+func buildIdentifiers(value string) []string {
+	identifiers := make([]string, 0)
+	for _, s := range strings.Split(value, ".") {
+		s = strings.Map(func(r rune) rune {
+			if semver.ValidateBuildIdentifier(string(r)) {
+				return r
+			}
+			return '-'
+		}, strings.TrimSpace(s))
+		if s != "" {
+			identifiers = append(identifiers, s)
+		}
+	}
+	return identifiers
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMetadata(t *testing.T) {
+	dir, err := os.MkdirTemp("", "buildMetadataTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	content := `version: "1.0.0-rc.2"
+build-metadata: [sha, commits, env:VB_TEST_BUILD]
+`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+
+	newGitRepo(t, dir)
+	runGit(t, dir, "tag", "v1.0.0-rc.1")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Second commit")
+	sha := runGit(t, dir, "rev-parse", "--short=7", "HEAD")
+	t.Setenv("VB_TEST_BUILD", "7_x")
+
+	// the configured providers replace the build label and number of pre-build
+	vb, err := NewVersionBump(config.Options{ConfigPath: filePath, BumpPart: "pre-build"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0-rc.2+sha."+sha+".commits.1.7-x", vb.GetNewVersion())
+
+	// as do chains ending with pre-build
+	vb, err = NewVersionBump(config.Options{ConfigPath: filePath, BumpPart: "release,pre-build"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0+sha."+sha+".commits.1.7-x", vb.GetNewVersion())
+
+	// other strategies only use the providers given on the command line
+	vb, err = NewVersionBump(config.Options{ConfigPath: filePath, BumpPart: "release"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", vb.GetNewVersion())
+
+	vb, err = NewVersionBump(config.Options{ConfigPath: filePath, BumpPart: "release", BuildMetadata: []string{"dirty", "sha"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0+sha."+sha, vb.GetNewVersion())
+	// the metadata doesn't change while the bump is performed
+	if err := os.WriteFile(filePath, []byte(content+"# changed\n"), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+	assert.Equal(t, "1.0.0+sha."+sha, vb.GetNewVersion())
+	// the dirty provider allows bumping with pending changes
+	assert.True(t, vb.allowsPendingChanges())
+	vb.Options.BuildMetadata = []string{"sha"}
+	assert.False(t, vb.allowsPendingChanges())
+
+	now := time.Date(2024, 10, 16, 8, 30, 5, 0, time.UTC)
+	identifiers, err := vb.resolveBuildMetadata([]string{"dirty", "date", "timestamp"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dirty", "20241016", "20241016083005"}, identifiers)
+
+	for _, name := range ciBuildNumberVars {
+		t.Setenv(name, "")
+	}
+	t.Setenv("GITHUB_RUN_NUMBER", "42")
+	identifiers, err = vb.resolveBuildMetadata([]string{"ci"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ci", "42"}, identifiers)
+
+	_, err = vb.resolveBuildMetadata([]string{"env:VB_TEST_UNSET"}, now)
+	assert.Error(t, err)
+
+	// the git providers aren't available when git is disabled
+	vb.Options.NoGit = true
+	for _, provider := range []string{"sha", "commits", "dirty"} {
+		_, err = vb.resolveBuildMetadata([]string{provider}, now)
+		assert.Error(t, err, provider)
+	}
+	identifiers, err = vb.resolveBuildMetadata([]string{"date"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"20241016"}, identifiers)
+}
@@ -4,42 +4,122 @@ import (
 	"fmt"
 	"github.com/ptgoetz/go-versionbump/internal/utils"
 	"github.com/ptgoetz/go-versionbump/pkg/semver"
-	"gopkg.in/yaml.v3"
 	"os"
 	"path"
+	"path/filepath"
 	"sort"
+	"strings"
 )
 
 const (
 	DefaultGitCommitTemplate     = "bump version {old} --> {new}"
 	DefaultGitTagTemplate        = "v{new}"
 	DefaultGitTagMessageTemplate = "Release version {new}"
+	DefaultDescribeTemplate      = `{{pre .Next "dev" .Commits}}+g{{.SHA}}{{if .Dirty}}-dirty{{end}}`
 	DefaultBuildLabel            = "build"
 )
 
+// Pre-release label orders
+const (
+	// LabelOrderConfig orders pre-release labels as they are listed in the configuration
+	LabelOrderConfig = "config"
+	// LabelOrderLexical orders pre-release labels lexically
+	LabelOrderLexical = "lexical"
+)
+
+// Version policies
+const (
+	// VersionPolicyStrict refuses new versions that are already tagged or lower than the latest tagged version on the
+	// same release line
+	VersionPolicyStrict = "strict"
+	// VersionPolicyWarn warns about the versions VersionPolicyStrict refuses
+	VersionPolicyWarn = "warn"
+	// VersionPolicyOff doesn't check new versions against the tagged versions
+	VersionPolicyOff = "off"
+)
+
 var (
 	DetaultPreReleaseLabels = []string{"alpha", "beta", "rc"}
 	DefaultVersion          = "0.0.0"
+	DefaultFourPartVersion  = "0.0.0.0"
+	// BuildMetadataProviders are the sources of build metadata (see `build-metadata`). In addition, `env:NAME` adds the
+	// value of an environment variable.
+	BuildMetadataProviders = []string{"sha", "timestamp", "date", "commits", "dirty", "ci"}
+	// ConfigFileNames are the configuration file names searched for, in order of precedence
+	ConfigFileNames = []string{"versionbump.yaml", ".versionbump.yaml", "versionbump.json", "versionbump.toml"}
 )
 
 // Config represents the version bump configuration.
 type Config struct {
-	Version               string          `yaml:"version"`
-	BuildLabel            string          `yaml:"build-label"`
-	PreReleaseLabels      []string        `yaml:"prerelease-labels"`
-	GitCommit             bool            `yaml:"git-commit"`
-	GitCommitTemplate     string          `yaml:"git-commit-template"`
-	GitSign               bool            `yaml:"git-sign"`
-	GitTag                bool            `yaml:"git-tag"`
-	GitTagTemplate        string          `yaml:"git-tag-template"`
-	GitTagMessageTemplate string          `yaml:"git-tag-message-template"`
-	Files                 []VersionedFile `yaml:"files"`
+	Extends               []string        `yaml:"extends,omitempty" json:"extends,omitempty" toml:"extends,omitempty"`
+	Version               string          `yaml:"version" json:"version" toml:"version"`
+	VersionScheme         string          `yaml:"version-scheme" json:"version-scheme" toml:"version-scheme"`
+	BuildLabel            string          `yaml:"build-label" json:"build-label" toml:"build-label"`
+	PreReleaseLabels      []string        `yaml:"prerelease-labels" json:"prerelease-labels" toml:"prerelease-labels"`
+	PreReleaseLabelOrder  string          `yaml:"prerelease-label-order" json:"prerelease-label-order" toml:"prerelease-label-order"`
+	VersionPolicy         string          `yaml:"version-policy" json:"version-policy" toml:"version-policy"`
+	PreReleaseChannels    []Channel       `yaml:"prerelease-channels,omitempty" json:"prerelease-channels,omitempty" toml:"prerelease-channels,omitempty"`
+	Strategies            []Strategy      `yaml:"strategies,omitempty" json:"strategies,omitempty" toml:"strategies,omitempty"`
+	BuildMetadata         []string        `yaml:"build-metadata,omitempty" json:"build-metadata,omitempty" toml:"build-metadata,omitempty"`
+	GitCommit             bool            `yaml:"git-commit" json:"git-commit" toml:"git-commit"`
+	GitCommitTemplate     string          `yaml:"git-commit-template" json:"git-commit-template" toml:"git-commit-template"`
+	GitSign               bool            `yaml:"git-sign" json:"git-sign" toml:"git-sign"`
+	GitTag                bool            `yaml:"git-tag" json:"git-tag" toml:"git-tag"`
+	GitTagTemplate        string          `yaml:"git-tag-template" json:"git-tag-template" toml:"git-tag-template"`
+	GitTagMessageTemplate string          `yaml:"git-tag-message-template" json:"git-tag-message-template" toml:"git-tag-message-template"`
+	DescribeTemplate      string          `yaml:"describe-template" json:"describe-template" toml:"describe-template"`
+	Files                 []VersionedFile `yaml:"files" json:"files" toml:"files"`
+	VersionSource         *VersionSource  `yaml:"version-source,omitempty" json:"version-source,omitempty" toml:"version-source,omitempty"`
+	// Sources records the configuration file each value came from (see Source)
+	Sources map[string]string `yaml:"-" json:"-" toml:"-"`
 }
 
 // VersionedFile represents the file to be updated with the new version.
+// The version is either updated by replacing the `Replace` patterns, or by updating the value of `Key` in a YAML or
+// JSON file. `Format` is the version dialect of the file (e.g. pep440), the version is written as is if empty.
 type VersionedFile struct {
-	Path    string   `yaml:"path"`
-	Replace []string `yaml:"replace"`
+	Path    string   `yaml:"path" json:"path" toml:"path"`
+	Replace []string `yaml:"replace,omitempty" json:"replace,omitempty" toml:"replace,omitempty"`
+	Key     string   `yaml:"key,omitempty" json:"key,omitempty" toml:"key,omitempty"`
+	Format  string   `yaml:"format,omitempty" json:"format,omitempty" toml:"format,omitempty"`
+}
+
+// GetDialect returns the version dialect of the file.
+func (f *VersionedFile) GetDialect() semver.Dialect {
+	if f.Format == "" {
+		return semver.DialectSemVer
+	}
+	return semver.Dialect(f.Format)
+}
+
+// Channel represents a named pre-release channel (e.g. nightly builds), numbered independently of other channels with
+// its own label, e.g. `1.2.0-nightly.3`.
+type Channel struct {
+	Name string `yaml:"name" json:"name" toml:"name"`
+	// The pre-release label of the channel, defaults to the name
+	Label string `yaml:"label,omitempty" json:"label,omitempty" toml:"label,omitempty"`
+}
+
+// Strategy represents a custom bump strategy, applying other strategies in order, e.g. `finalize` with the steps
+// `release` and `patch`. Steps are built-in strategies or strategies defined before it.
+type Strategy struct {
+	Name string `yaml:"name" json:"name" toml:"name"`
+	// The description shown in the help, defaults to a description of the steps
+	Description string   `yaml:"description,omitempty" json:"description,omitempty" toml:"description,omitempty"`
+	Steps       []string `yaml:"steps" json:"steps" toml:"steps"`
+}
+
+// VersionSource represents an alternate source of the project version. The version is either read from (and written
+// back to) a file, or derived from the latest git tag.
+type VersionSource struct {
+	// The file containing the version
+	File string `yaml:"file,omitempty" json:"file,omitempty" toml:"file,omitempty"`
+	// A pattern with a `{version}` placeholder locating the version
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty" toml:"pattern,omitempty"`
+	// A dotted key locating the version in a YAML or JSON file
+	Key string `yaml:"key,omitempty" json:"key,omitempty" toml:"key,omitempty"`
+	// Derive the version from the latest git tag
+	LatestTag bool `yaml:"latest-tag,omitempty" json:"latest-tag,omitempty" toml:"latest-tag,omitempty"`
 }
 
 type GitMeta struct {
@@ -58,15 +138,49 @@ type Options struct {
 	ResetVersion string
 	NoGit        bool
 	NoColor      bool
+	Format       string
 	BumpPart     semver.BumpStrategy
-	InitOpts     InitOptions
+	Channel      string
+	// Force downgrades the refusals of the version policy to warnings
+	Force bool
+	// BuildMetadata are the build metadata providers given on the command line
+	BuildMetadata []string
+	// DescribeTemplate overrides the configured describe template
+	DescribeTemplate string
+	InitOpts         InitOptions
+	NextOpts         NextOptions
+	ShowOpts         ShowOptions
+}
+
+// ShowOptions are the options of the show command.
+type ShowOptions struct {
+	// Format is the output format: text, json, mermaid or dot
+	Format string
+	// Depth is the number of bumps each path is expanded to
+	Depth int
+}
+
+// NextOptions are the options of the next command.
+type NextOptions struct {
+	// From is the version to bump instead of the project version
+	From string
+	// Format is the version dialect to print the version in (e.g. pep440)
+	Format string
+	// Tag prints the git tag name of the version instead
+	Tag bool
 }
 
 type InitOptions struct {
-	File           string
-	NoInteractive  bool
-	InitialVersion string
-	ScanDirectory  bool
+	File             string
+	Format           string
+	NoInteractive    bool
+	InitialVersion   string
+	PreReleaseLabels []string
+	BuildLabel       string
+	GitCommit        bool
+	GitTag           bool
+	GitSign          bool
+	ScanDirectory    bool
 }
 
 func (o Options) IsResetVersion() bool {
@@ -78,6 +192,11 @@ func (vbm *GitMeta) String() string {
 		vbm.CommitMessage, vbm.TagMessage, vbm.TagName)
 }
 
+// IsLatestTagSource returns true if the project version is derived from the latest git tag.
+func (v Config) IsLatestTagSource() bool {
+	return v.VersionSource != nil && v.VersionSource.LatestTag
+}
+
 // IsGitRequired returns true if any of the Git options are enabled.
 func (v Config) IsGitRequired() bool {
 	return v.GitCommit || v.GitTag
@@ -93,64 +212,267 @@ func (v Config) HasLabel(label string) bool {
 	return false
 }
 
-// GetSortedLabels returns a sorted slice of pre-release labels
+// ParseVersion parses a version of the configured version scheme.
+func (v Config) ParseVersion(version string) (*semver.SemanticVersion, error) {
+	return semver.ParseWithScheme(version, semver.VersionScheme(v.VersionScheme))
+}
+
+// IsFourPart returns true if the project uses four-part versions (e.g. 1.2.3.4).
+func (v Config) IsFourPart() bool {
+	return v.VersionScheme == string(semver.SchemeFourPart)
+}
+
+// GetLabel returns the pre-release label of the channel.
+func (c Channel) GetLabel() string {
+	if c.Label == "" {
+		return c.Name
+	}
+	return c.Label
+}
+
+// GetChannel returns the pre-release channel with the given name.
+func (v Config) GetChannel(name string) (*Channel, error) {
+	names := make([]string, 0, len(v.PreReleaseChannels))
+	for _, c := range v.PreReleaseChannels {
+		if c.Name == name {
+			return &c, nil
+		}
+		names = append(names, c.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("unknown channel '%s', no prerelease-channels are configured", name)
+	}
+	return nil, fmt.Errorf("unknown channel '%s', expected one of %s", name, strings.Join(names, ", "))
+}
+
+// GetStrategies returns the custom strategies of the configuration, in the order they are defined.
+func (v Config) GetStrategies() ([]semver.Strategy, error) {
+	strategies := make([]semver.Strategy, 0, len(v.Strategies))
+	defined := make(map[string]semver.Strategy)
+	for _, def := range v.Strategies {
+		if !semver.ValidateStrategyName(def.Name) {
+			return nil, fmt.Errorf("invalid strategy name, names must be lowercase alphanumerics or '-': '%s'", def.Name)
+		}
+		if _, ok := semver.LookupStrategy(def.Name); ok {
+			return nil, fmt.Errorf("strategy '%s' is already defined", def.Name)
+		}
+		if _, ok := defined[def.Name]; ok {
+			return nil, fmt.Errorf("duplicate strategy '%s'", def.Name)
+		}
+		steps := make([]semver.Strategy, 0, len(def.Steps))
+		for _, name := range def.Steps {
+			step, ok := defined[name]
+			if !ok {
+				if step, ok = semver.LookupStrategy(name); !ok {
+					return nil, fmt.Errorf("unknown step '%s' of strategy '%s'", name, def.Name)
+				}
+			}
+			steps = append(steps, step)
+		}
+		strategy, err := semver.NewCompositeStrategy(def.Name, def.Description, steps...)
+		if err != nil {
+			return nil, err
+		}
+		defined[def.Name] = strategy
+		strategies = append(strategies, strategy)
+	}
+	return strategies, nil
+}
+
+// GetStrategy returns the strategy with the given name, either a custom strategy of the configuration or a
+// registered strategy.
+func (v Config) GetStrategy(name string) (semver.Strategy, error) {
+	strategies, err := v.GetStrategies()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range strategies {
+		if s.Name() == name {
+			return s, nil
+		}
+	}
+	if s, ok := semver.LookupStrategy(name); ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown strategy: %s", name)
+}
+
+// GetStrategyChain returns the strategy applying a chain of strategies in order, e.g. `minor,pre`. A chain of a
+// single strategy is the strategy itself.
+func (v Config) GetStrategyChain(chain string) (semver.Strategy, error) {
+	names, err := semver.SplitStrategyChain(chain)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]semver.Strategy, 0, len(names))
+	for _, name := range names {
+		step, err := v.GetStrategy(name)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 1 {
+		return steps[0], nil
+	}
+	return semver.ChainStrategies(steps...), nil
+}
+
+// IsBuildMetadataProvider returns true if the name is one of the BuildMetadataProviders, or `env:NAME`.
+func IsBuildMetadataProvider(name string) bool {
+	if strings.HasPrefix(name, "env:") {
+		return isEnvName(strings.TrimPrefix(name, "env:"))
+	}
+	for _, provider := range BuildMetadataProviders {
+		if name == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSortedLabels returns the pre-release labels in the order versions progress through them. That's the order they
+// are listed in, unless `prerelease-label-order` is `lexical`.
 func (v Config) GetSortedLabels() []string {
 	// Make a copy of the input slice to avoid modifying the original
 	sortedStrings := make([]string, len(v.PreReleaseLabels))
 	copy(sortedStrings, v.PreReleaseLabels)
 
-	// Sort the strings lexically
-	sort.Strings(sortedStrings)
-
+	if v.PreReleaseLabelOrder == LabelOrderLexical {
+		sort.Strings(sortedStrings)
+	}
 	return sortedStrings
 }
 
-// LoadConfig loads the configuration from a YAML file
-func LoadConfig(filePath string) (*Config, string, error) {
-	// Open the YAML file
-	configFile := path.Base(filePath)
-	file, err := os.Open(filePath)
+// FindConfig searches for a configuration file in the given directory and its parents. The search stops at the root
+// of the git repository containing the directory, or at the filesystem root.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
 	if err != nil {
-		return nil, "", fmt.Errorf("error opening config file: %w", err)
+		return "", err
 	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			fmt.Printf("Error closing config file: %v", err)
+	for {
+		for _, name := range ConfigFileNames {
+			candidate := filepath.Join(dir, name)
+			if utils.FileExists(candidate) {
+				return candidate, nil
+			}
 		}
-	}(file)
+		// don't search beyond the root of the git repository
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return "", fmt.Errorf("no configuration file (%s) found in %s or its parent directories",
+		strings.Join(ConfigFileNames, ", "), dir)
+}
+
+// LoadConfig loads the configuration from a YAML, JSON or TOML file. The format is detected by the file extension.
+func LoadConfig(filePath string) (*Config, string, error) {
+	configFile := path.Base(filePath)
 
-	// Parse the YAML file into the Config struct
-	var config Config
-	decoder := yaml.NewDecoder(file)
-	err = decoder.Decode(&config)
+	// Parse the file, merged with the files it extends, into the Config struct
+	config, err := decodeConfig(filePath)
 	if err != nil {
 		return nil, "", fmt.Errorf("error parsing config file: %w", err)
 	}
 
+	// environment overrides are applied on top of the values from the files
+	err = config.applyEnvOverrides()
+	if err != nil {
+		return nil, "", err
+	}
+	if !semver.ValidatePreReleaseLabels(config.PreReleaseLabels) {
+		return nil, "", fmt.Errorf("invalid pre-release labels, labels must be alphabetic: %v", config.PreReleaseLabels)
+	}
+	if !semver.ValidateBuildLabel(config.BuildLabel) {
+		return nil, "", fmt.Errorf("invalid build label, the label must be alphanumeric: %s", config.BuildLabel)
+	}
+	for _, c := range config.PreReleaseChannels {
+		if c.Name == "" || !semver.ValidatePreReleaseLabels([]string{c.GetLabel()}) {
+			return nil, "", fmt.Errorf("invalid pre-release channel '%s', channels require a name and an alphabetic label", c.Name)
+		}
+	}
+	for _, provider := range config.BuildMetadata {
+		if !IsBuildMetadataProvider(provider) {
+			return nil, "", fmt.Errorf("invalid build metadata provider, expected one of %s or env:NAME: %s",
+				strings.Join(BuildMetadataProviders, ", "), provider)
+		}
+	}
+	for _, file := range config.Files {
+		if file.Format != "" && !isSettingValue("files.format", file.Format) {
+			return nil, "", fmt.Errorf("invalid format of %s, expected one of %s: %s", file.Path,
+				strings.Join(settingValues["files.format"], ", "), file.Format)
+		}
+	}
+	if _, err := config.GetStrategies(); err != nil {
+		return nil, "", err
+	}
+	if config.VersionScheme != "" && !isSettingValue("version-scheme", config.VersionScheme) {
+		return nil, "", fmt.Errorf("invalid version-scheme, expected one of %s: %s",
+			strings.Join(settingValues["version-scheme"], ", "), config.VersionScheme)
+	}
+	if config.PreReleaseLabelOrder != "" && !isSettingValue("prerelease-label-order", config.PreReleaseLabelOrder) {
+		return nil, "", fmt.Errorf("invalid prerelease-label-order, expected one of %s: %s",
+			strings.Join(settingValues["prerelease-label-order"], ", "), config.PreReleaseLabelOrder)
+	}
+	if config.VersionPolicy != "" && !isSettingValue("version-policy", config.VersionPolicy) {
+		return nil, "", fmt.Errorf("invalid version-policy, expected one of %s: %s",
+			strings.Join(settingValues["version-policy"], ", "), config.VersionPolicy)
+	}
+
 	// make sure we can resolve the parent directory
 	root, err := utils.ParentDirAbsolutePath(filePath)
 	if err != nil {
 		return nil, "", fmt.Errorf("error getting parent directory: %w", err)
 	}
 
-	// validate the version string is not empty
-	if config.Version == "" {
-		return nil, "", fmt.Errorf("version string is required")
+	configPtr := &config
+	if config.VersionSource != nil {
+		if config.Version != "" {
+			return nil, "", fmt.Errorf("version and version-source are mutually exclusive")
+		}
+		err = configPtr.loadVersionSource(root)
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
+		// include the config file as a file to update. The version is updated in place, so it may be written in any
+		// style, and comments and formatting are preserved.
+		configPtr.Files = append(configPtr.Files, VersionedFile{Path: configFile, Key: "version"})
+		configPtr.Sources[fmt.Sprintf("files[%s]", configFile)] = filePath
 	}
 
-	if !semver.ValidateSemVersion(config.Version) {
-		return nil, "", fmt.Errorf("invalid version string: %s", config.Version)
-	}
+	// the latest tag version can only be resolved once git is available
+	if !config.IsLatestTagSource() {
+		// validate the version string is not empty
+		if config.Version == "" {
+			return nil, "", fmt.Errorf("version string is required (or version-source, e.g. latest-tag: true)")
+		}
 
-	configPtr := &config
-	// include the config file as a file to update
-	configPtr.Files = append(configPtr.Files, VersionedFile{Path: configFile, Replace: []string{"version: \"{version}\""}})
+		if _, err := config.ParseVersion(config.Version); err != nil {
+			return nil, "", fmt.Errorf("invalid version string: %s", config.Version)
+		}
+	}
 
 	// set the default pre-release labels if not provided
 	if len(config.PreReleaseLabels) < 1 {
 		configPtr.PreReleaseLabels = []string{"alpha", "beta", "rc"}
 	}
+	if config.PreReleaseLabelOrder == "" {
+		configPtr.PreReleaseLabelOrder = LabelOrderConfig
+	}
+	if config.VersionPolicy == "" {
+		configPtr.VersionPolicy = VersionPolicyStrict
+	}
+	if config.VersionScheme == "" {
+		configPtr.VersionScheme = string(semver.SchemeSemVer)
+	}
 
 	// set defaults if not overridden
 	if config.GitTagTemplate == "" {
@@ -159,6 +481,9 @@ func LoadConfig(filePath string) (*Config, string, error) {
 	if config.GitCommitTemplate == "" {
 		configPtr.GitCommitTemplate = DefaultGitCommitTemplate
 	}
+	if config.DescribeTemplate == "" {
+		configPtr.DescribeTemplate = DefaultDescribeTemplate
+	}
 
 	if config.GitTagMessageTemplate == "" {
 		configPtr.GitTagMessageTemplate = DefaultGitTagMessageTemplate
@@ -170,14 +495,18 @@ func LoadConfig(filePath string) (*Config, string, error) {
 func NewConfig() *Config {
 	return &Config{
 		Version:               DefaultVersion,
+		VersionScheme:         string(semver.SchemeSemVer),
 		BuildLabel:            DefaultBuildLabel,
 		PreReleaseLabels:      DetaultPreReleaseLabels,
+		PreReleaseLabelOrder:  LabelOrderConfig,
+		VersionPolicy:         VersionPolicyStrict,
 		GitCommit:             false,
 		GitCommitTemplate:     DefaultGitCommitTemplate,
 		GitSign:               false,
 		GitTag:                false,
 		GitTagTemplate:        DefaultGitTagTemplate,
 		GitTagMessageTemplate: DefaultGitTagMessageTemplate,
+		DescribeTemplate:      DefaultDescribeTemplate,
 		Files:                 []VersionedFile{},
 	}
 }
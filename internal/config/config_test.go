@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
 )
 
 // TestLoadConfig tests the LoadConfig function
@@ -110,3 +113,263 @@ files:
 		t.Fatal("Expected an error when loading an invalid YAML file, but got none")
 	}
 }
+
+// TestLoadConfigTagMode tests that a config without a version derives the version from git tags
+func TestLoadConfigTagMode(t *testing.T) {
+	dir, err := os.MkdirTemp("", "loadConfigTagModeTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	yamlContent := `
+version-source:
+  latest-tag: true
+git-tag-template: "release-{new}"
+files:
+  - path: "README.md"
+    replace:
+      - "v{version}"
+`
+	if err := os.WriteFile(filePath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+
+	config, _, err := LoadConfig(filePath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !config.IsLatestTagSource() {
+		t.Errorf("Expected the version to be derived from the latest tag")
+	}
+	if !config.GitTag {
+		t.Errorf("Expected git-tag to be implied, but got false")
+	}
+	// the config file has no version, so it is not a tracked file
+	if len(config.Files) != 1 {
+		t.Fatalf("Expected 1 file, but got %d", len(config.Files))
+	}
+
+	// a config without a version isn't tag-driven unless configured, e.g. when the version key is misspelled
+	if err := os.WriteFile(filePath, []byte("verison: \"1.2.3\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+	if _, _, err := LoadConfig(filePath); err == nil {
+		t.Errorf("Expected an error for a config without a version")
+	}
+	if err := os.WriteFile(filePath, []byte("git-tag-template: \"v{new}\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+	if _, _, err := LoadConfig(filePath); err == nil || !strings.Contains(err.Error(), "version string is required") {
+		t.Errorf("Expected a missing version error, but got %v", err)
+	}
+}
+
+// TestFindConfig tests searching for a configuration file in parent directories
+func TestLoadConfigFourPart(t *testing.T) {
+	dir, err := os.MkdirTemp("", "loadConfigFourPartTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		content    string
+		shouldFail bool
+	}{
+		{"version: \"1.2.3.4\"\nversion-scheme: four-part\n", false},
+		{"version: \"1.2.3\"\nversion-scheme: four-part\n", true},
+		{"version: \"1.2.3.4\"\n", true},
+		{"version: \"1.2.3.4\"\nversion-scheme: calver\n", true},
+	}
+	for _, test := range tests {
+		filePath := filepath.Join(dir, "versionbump.yaml")
+		if err := os.WriteFile(filePath, []byte(test.content), 0644); err != nil {
+			t.Fatalf("Failed to write to YAML config file: %v", err)
+		}
+		config, _, err := LoadConfig(filePath)
+		if test.shouldFail {
+			if err == nil {
+				t.Errorf("Expected an error for config %q", test.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+		if !config.IsFourPart() {
+			t.Errorf("Expected a four-part version scheme, but got %s", config.VersionScheme)
+		}
+		if v, err := config.ParseVersion(config.Version); err != nil || v.RootVersion().Revision() != 4 {
+			t.Errorf("Expected version 1.2.3.4 to be parsed, but got %v (%v)", v, err)
+		}
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	dir, err := os.MkdirTemp("", "findConfigTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// <dir>/versionbump.yaml is outside of the git repository at <dir>/repo
+	repo := filepath.Join(dir, "repo")
+	subdir := filepath.Join(repo, "pkg", "sub")
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatalf("Failed to create subdir: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "versionbump.yaml"), []byte("version: \"1.0.0\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	if _, err := FindConfig(subdir); err == nil {
+		t.Errorf("Expected the search to stop at the git repository root, but a config was found")
+	}
+
+	expected := filepath.Join(repo, ".versionbump.yaml")
+	if err := os.WriteFile(expected, []byte("version: \"1.0.0\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	found, err := FindConfig(subdir)
+	if err != nil {
+		t.Fatalf("FindConfig failed: %v", err)
+	}
+	if found != expected {
+		t.Errorf("Expected config file '%s', but got '%s'", expected, found)
+	}
+
+	// versionbump.yaml takes precedence over .versionbump.yaml
+	expected = filepath.Join(repo, "versionbump.yaml")
+	if err := os.WriteFile(expected, []byte("version: \"1.0.0\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	found, err = FindConfig(subdir)
+	if err != nil {
+		t.Fatalf("FindConfig failed: %v", err)
+	}
+	if found != expected {
+		t.Errorf("Expected config file '%s', but got '%s'", expected, found)
+	}
+}
+
+// TestGetSortedLabels tests that labels keep their configured order unless lexical order is configured
+func TestGetSortedLabels(t *testing.T) {
+	config := Config{PreReleaseLabels: []string{"snapshot", "milestone", "rc"}, PreReleaseLabelOrder: LabelOrderConfig}
+	expected := []string{"snapshot", "milestone", "rc"}
+	if actual := config.GetSortedLabels(); strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected labels %v, but got %v", expected, actual)
+	}
+
+	config.PreReleaseLabelOrder = LabelOrderLexical
+	expected = []string{"milestone", "rc", "snapshot"}
+	if actual := config.GetSortedLabels(); strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected labels %v, but got %v", expected, actual)
+	}
+	if config.PreReleaseLabels[0] != "snapshot" {
+		t.Errorf("Expected the configured labels to be unchanged, but got %v", config.PreReleaseLabels)
+	}
+}
+
+func TestGetChannel(t *testing.T) {
+	config := Config{PreReleaseChannels: []Channel{{Name: "nightly"}, {Name: "beta", Label: "preview"}}}
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"nightly", "nightly"},
+		{"beta", "preview"},
+	}
+	for _, test := range tests {
+		channel, err := config.GetChannel(test.name)
+		if err != nil {
+			t.Fatalf("Unexpected error for channel %s: %v", test.name, err)
+		}
+		if channel.GetLabel() != test.expected {
+			t.Errorf("Expected label %s for channel %s, but got %s", test.expected, test.name, channel.GetLabel())
+		}
+	}
+
+	if _, err := config.GetChannel("rc"); err == nil {
+		t.Errorf("Expected an error for an unknown channel")
+	}
+}
+
+func TestGetStrategies(t *testing.T) {
+	config := Config{
+		PreReleaseLabels: []string{"alpha", "beta", "rc"},
+		Strategies: []Strategy{
+			{Name: "finalize", Steps: []string{"release", "patch"}},
+			{Name: "ship", Description: "Finalize, then start the next minor.", Steps: []string{"finalize", "new-pre-minor"}},
+		},
+	}
+	strategies, err := config.GetStrategies()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(strategies) != 2 {
+		t.Fatalf("Expected 2 strategies, but got %d", len(strategies))
+	}
+	if strategies[0].Describe() != "Bump with release, then patch." {
+		t.Errorf("Unexpected description of finalize: %s", strategies[0].Describe())
+	}
+
+	tests := []struct {
+		strategy string
+		version  string
+		expected string
+	}{
+		{"finalize", "1.2.3-rc.1", "1.2.4"},
+		{"ship", "1.2.3-rc.1", "1.3.0-alpha"},
+		{"major", "1.2.3", "2.0.0"},
+	}
+	for _, test := range tests {
+		strategy, err := config.GetStrategy(test.strategy)
+		if err != nil {
+			t.Fatalf("Unexpected error for strategy %s: %v", test.strategy, err)
+		}
+		v, err := config.ParseVersion(test.version)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %s: %v", test.version, err)
+		}
+		bumped, err := strategy.Apply(v, semver.StrategyConfig{PreReleaseLabels: config.GetSortedLabels()})
+		if err != nil {
+			t.Fatalf("Unexpected error applying %s to %s: %v", test.strategy, test.version, err)
+		}
+		if bumped.String() != test.expected {
+			t.Errorf("Expected %s %s to be %s, but got %s", test.strategy, test.version, test.expected, bumped)
+		}
+	}
+
+	if _, err := config.GetStrategy("deploy"); err == nil {
+		t.Errorf("Expected an error for an unknown strategy")
+	}
+
+	chain, err := config.GetStrategyChain("finalize, new-pre-minor")
+	if err != nil {
+		t.Fatalf("Unexpected error for a strategy chain: %v", err)
+	}
+	bumped, err := chain.Apply(semver.MustParse("1.2.3-rc.1"), semver.StrategyConfig{PreReleaseLabels: config.GetSortedLabels()})
+	if err != nil || bumped.String() != "1.3.0-alpha" {
+		t.Errorf("Expected finalize,new-pre-minor of 1.2.3-rc.1 to be 1.3.0-alpha, but got %s (%v)", bumped, err)
+	}
+	if _, err := config.GetStrategyChain("minor,deploy"); err == nil {
+		t.Errorf("Expected an error for a chain with an unknown strategy")
+	}
+	invalid := []Strategy{
+		{Name: "patch", Steps: []string{"minor"}},
+		{Name: "deploy", Steps: []string{"ship"}},
+		{Name: "empty"},
+		{Name: "Finalize", Steps: []string{"release"}},
+	}
+	for _, s := range invalid {
+		config := Config{Strategies: []Strategy{s}}
+		if _, err := config.GetStrategies(); err == nil {
+			t.Errorf("Expected an error for strategy %+v", s)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetValue sets a single-valued setting (e.g. `git-sign`) in a configuration file. An existing value is updated in
+// place, preserving comments and formatting, and a missing setting is added. The file is restored if the resulting
+// configuration is invalid.
+func SetValue(filePath string, key string, value string) error {
+	field, err := settingField(key)
+	if err != nil {
+		return err
+	}
+	if field.Type.Kind() == reflect.Bool {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s, expected a boolean: %s", key, value)
+		}
+		value = strconv.FormatBool(b)
+	}
+
+	original, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := findKey(filePath, key); err == nil {
+		err = WriteKey(filePath, key, value)
+		if err != nil {
+			return err
+		}
+	} else {
+		content, err := insertValue(filePath, original, key, value, field.Type.Kind() == reflect.String)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filePath, content, info.Mode()); err != nil {
+			return err
+		}
+	}
+
+	if _, _, err := LoadConfig(filePath); err != nil {
+		if restoreErr := os.WriteFile(filePath, original, info.Mode()); restoreErr != nil {
+			return fmt.Errorf("%w (restoring %s failed: %v)", err, filePath, restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// settingField returns the Config field of a single-valued setting.
+func settingField(key string) (reflect.StructField, error) {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		keys = append(keys, name)
+		if name != key {
+			continue
+		}
+		kind := t.Field(i).Type.Kind()
+		if kind != reflect.String && kind != reflect.Bool {
+			return reflect.StructField{}, fmt.Errorf("%s is not a single value and can't be set", key)
+		}
+		return t.Field(i), nil
+	}
+	if suggestion := closestKey(key, keys); suggestion != "" {
+		return reflect.StructField{}, fmt.Errorf("unknown setting '%s', did you mean '%s'?", key, suggestion)
+	}
+	return reflect.StructField{}, fmt.Errorf("unknown setting '%s'", key)
+}
+
+// insertValue adds a top-level setting to the content of a configuration file.
+func insertValue(filePath string, content []byte, key string, value string, isString bool) ([]byte, error) {
+	format := FormatFromPath(filePath)
+	formatted := value
+	if isString {
+		var err error
+		formatted, err = quoteString(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	text := string(content)
+
+	switch format {
+	case FormatJSON:
+		// insert the setting before the first one, with the same indentation
+		node, err := parseConfigNode(filePath, content)
+		if err != nil {
+			return nil, err
+		}
+		if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+			return nil, fmt.Errorf("unable to add %s to %s", key, filePath)
+		}
+		first := node.Content[0]
+		lines := strings.SplitAfter(text, "\n")
+		line := lines[first.Line-1]
+		start := len(string([]rune(line)[:first.Column-1]))
+		entry := fmt.Sprintf("%q: %s, ", key, formatted)
+		if strings.TrimSpace(line[:start]) == "" {
+			entry = fmt.Sprintf("%q: %s,\n%s", key, formatted, line[:start])
+		}
+		lines[first.Line-1] = line[:start] + entry + line[start:]
+		return []byte(strings.Join(lines, "")), nil
+	case FormatTOML:
+		// top-level settings must precede the first table
+		entry := fmt.Sprintf("%s = %s\n", key, formatted)
+		lines := strings.SplitAfter(text, "\n")
+		for i, line := range lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "[") {
+				// keep the blank lines separating the table
+				for i > 0 && strings.TrimSpace(lines[i-1]) == "" {
+					i--
+				}
+				lines[i] = entry + lines[i]
+				return []byte(strings.Join(lines, "")), nil
+			}
+		}
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		return []byte(text + entry), nil
+	default:
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		return []byte(fmt.Sprintf("%s%s: %s\n", text, key, formatted)), nil
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSetValue tests setting values in place, and adding missing settings, in each format
+func TestSetValue(t *testing.T) {
+	dir, err := os.MkdirTemp("", "setValueTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name     string
+		content  string
+		key      string
+		value    string
+		expected string
+	}{
+		{
+			"versionbump.yaml",
+			"# project version\nversion: 1.2.3 # managed by versionbump\ngit-sign: true\n",
+			"git-sign", "0",
+			"# project version\nversion: 1.2.3 # managed by versionbump\ngit-sign: false\n",
+		},
+		{
+			"versionbump.yaml",
+			"version: '1.2.3'\n",
+			"git-tag-template", "{new}",
+			"version: '1.2.3'\ngit-tag-template: \"{new}\"\n",
+		},
+		{
+			"versionbump.yaml",
+			"version: \"1.2.3\"\nbuild-label: build # for CI\n",
+			"build-label", "ci",
+			"version: \"1.2.3\"\nbuild-label: ci # for CI\n",
+		},
+		{
+			"versionbump.json",
+			"{\n  \"version\": \"1.2.3\",\n  \"git-tag\": false\n}\n",
+			"git-tag", "true",
+			"{\n  \"version\": \"1.2.3\",\n  \"git-tag\": true\n}\n",
+		},
+		{
+			"versionbump.json",
+			"{\n  \"version\": \"1.2.3\"\n}\n",
+			"build-label", "ci",
+			"{\n  \"build-label\": \"ci\",\n  \"version\": \"1.2.3\"\n}\n",
+		},
+		{
+			"versionbump.toml",
+			"version = \"1.2.3\"\n\n[[files]]\npath = \"README.md\"\nreplace = [\"v{version}\"]\n",
+			"git-commit", "1",
+			"version = \"1.2.3\"\ngit-commit = true\n\n[[files]]\npath = \"README.md\"\nreplace = [\"v{version}\"]\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name+"/"+test.key, func(t *testing.T) {
+			filePath := filepath.Join(dir, test.name)
+			if err := os.WriteFile(filePath, []byte(test.content), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+			if err := SetValue(filePath, test.key, test.value); err != nil {
+				t.Fatalf("SetValue failed: %v", err)
+			}
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read config file: %v", err)
+			}
+			if string(content) != test.expected {
+				t.Errorf("Expected content %q, but got %q", test.expected, string(content))
+			}
+		})
+	}
+}
+
+// TestSetValueInvalid tests that invalid values are rejected and leave the file unchanged
+func TestSetValueInvalid(t *testing.T) {
+	dir, err := os.MkdirTemp("", "setValueInvalidTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	content := "version: \"1.2.3\"\nbuild-label: \"build\"\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"build-label", "not-valid"},
+		{"version", "1.2"},
+		{"git-sign", "maybe"},
+		{"git-tags", "true"},
+		{"files", "README.md"},
+	}
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			if err := SetValue(filePath, test.key, test.value); err == nil {
+				t.Errorf("Expected an error, but got none")
+			}
+			actual, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read config file: %v", err)
+			}
+			if string(actual) != content {
+				t.Errorf("Expected the config file to be unchanged, but got %q", string(actual))
+			}
+		})
+	}
+}
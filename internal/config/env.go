@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EnvOverridePrefix is the prefix of environment variables overriding configuration values.
+const EnvOverridePrefix = "VERSIONBUMP_"
+
+// envOverrideExcluded are the keys that can't be overridden with environment variables, because they locate the
+// version or are not single values.
+var envOverrideExcluded = map[string]bool{
+	"version":             true,
+	"extends":             true,
+	"files":               true,
+	"prerelease-channels": true,
+	"strategies":          true,
+	"version-source":      true,
+}
+
+// InterpolateEnv expands `${NAME}` and `${NAME:-default}` environment variable references in a string. The default is
+// used when the variable is unset or empty. Use `$$` for a literal `$`.
+func InterpolateEnv(s string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			sb.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference in: %s", s)
+			}
+			expr := s[i+2 : i+end]
+			name, def, hasDefault := strings.Cut(expr, ":-")
+			if !isEnvName(name) {
+				return "", fmt.Errorf("invalid variable name '%s' in: %s", name, s)
+			}
+			sb.WriteString(lookupEnv(name, def, hasDefault))
+			i += end
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// envReference matches a `${NAME}` or `${NAME:-default}` environment variable reference.
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// interpolatePattern expands the `${NAME}` and `${NAME:-default}` environment variable references in a pattern matched
+// against file contents (e.g. a `replace` pattern). Any other text, such as `$$` or a GitHub Actions expression
+// (`${{ github.ref }}`), is kept as is, since it is likely part of the content being matched. So is a `$` before the
+// `{version}` placeholder.
+func interpolatePattern(s string) string {
+	return envReference.ReplaceAllStringFunc(s, func(ref string) string {
+		if ref == "$"+versionPlaceholder {
+			return ref
+		}
+		name, def, hasDefault := strings.Cut(ref[2:len(ref)-1], ":-")
+		return lookupEnv(name, def, hasDefault)
+	})
+}
+
+// lookupEnv returns the value of an environment variable, or the default when it is unset or empty, if there is one.
+func lookupEnv(name string, def string, hasDefault bool) string {
+	value, ok := os.LookupEnv(name)
+	if hasDefault && (!ok || value == "") {
+		return def
+	}
+	return value
+}
+
+// isEnvName returns true if the given string is a valid environment variable name.
+func isEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !(c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (i > 0 && c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// patternKeys are the keys of patterns matched against file contents, which only have their environment variable
+// references expanded (see interpolatePattern).
+var patternKeys = map[string]bool{
+	"replace": true,
+	"pattern": true,
+}
+
+// interpolateValues expands environment variable references in all string values of the raw configuration values,
+// except for the version itself.
+func interpolateValues(values map[string]interface{}) error {
+	for key, value := range values {
+		if key == "version" {
+			continue
+		}
+		interpolated, err := interpolateValue(key, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		values[key] = interpolated
+	}
+	return nil
+}
+
+// interpolateValue expands environment variable references in the raw configuration value of the given key.
+func interpolateValue(key string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if patternKeys[key] {
+			return interpolatePattern(v), nil
+		}
+		return InterpolateEnv(v)
+	case []interface{}:
+		for i, item := range v {
+			interpolated, err := interpolateValue(key, item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = interpolated
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			interpolated, err := interpolateValue(key, item)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = interpolated
+		}
+	}
+	return value, nil
+}
+
+// EnvOverrideName returns the name of the environment variable overriding the given key, e.g. `VERSIONBUMP_GIT_SIGN`
+// for `git-sign`.
+func EnvOverrideName(key string) string {
+	return EnvOverridePrefix + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// applyEnvOverrides overrides configuration values with `VERSIONBUMP_<KEY>` environment variables. Lists are given
+// as comma-separated values.
+func (v *Config) applyEnvOverrides() error {
+	rv := reflect.ValueOf(v).Elem()
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" || envOverrideExcluded[key] {
+			continue
+		}
+		name := EnvOverrideName(key)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		field := rv.Field(i)
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s, expected a boolean: %s", name, value)
+			}
+			field.SetBool(b)
+		case reflect.Slice:
+			values := make([]string, 0)
+			for _, s := range strings.Split(value, ",") {
+				if s = strings.TrimSpace(s); s != "" {
+					values = append(values, s)
+				}
+			}
+			field.Set(reflect.ValueOf(values))
+		default:
+			continue
+		}
+		if v.Sources == nil {
+			v.Sources = make(map[string]string)
+		}
+		v.Sources[key] = "env:" + name
+	}
+	return nil
+}
+
+// HasEnvOverrides returns true if any configuration value was overridden with an environment variable.
+func (v Config) HasEnvOverrides() bool {
+	for _, source := range v.Sources {
+		if strings.HasPrefix(source, "env:") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInterpolateEnv(t *testing.T) {
+	t.Setenv("VB_TEST_SET", "value")
+	t.Setenv("VB_TEST_EMPTY", "")
+
+	tests := []struct {
+		input      string
+		expected   string
+		shouldFail bool
+	}{
+		{"plain {version}", "plain {version}", false},
+		{"${VB_TEST_SET}", "value", false},
+		{"a-${VB_TEST_SET}-b", "a-value-b", false},
+		{"${VB_TEST_UNSET}", "", false},
+		{"${VB_TEST_UNSET:-default}", "default", false},
+		{"${VB_TEST_EMPTY:-default}", "default", false},
+		{"${VB_TEST_SET:-default}", "value", false},
+		{"$${VB_TEST_SET}", "${VB_TEST_SET}", false},
+		{"cost: $5", "cost: $5", false},
+		{"${VB_TEST_SET", "", true},
+		{"${1INVALID}", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			actual, err := InterpolateEnv(test.input)
+			if test.shouldFail {
+				if err == nil {
+					t.Errorf("Expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if actual != test.expected {
+				t.Errorf("Expected '%s', but got '%s'", test.expected, actual)
+			}
+		})
+	}
+}
+
+// TestLoadConfigEnv tests environment variable interpolation and overrides
+func TestLoadConfigEnv(t *testing.T) {
+	dir, err := os.MkdirTemp("", "loadConfigEnvTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	yamlContent := `
+version: "1.0.0"
+git-commit: true
+git-sign: true
+git-tag-template: "${VB_TEST_TAG_PREFIX:-v}{new}"
+files:
+  - path: "${VB_TEST_DIR:-docs}/README.md"
+    replace:
+      - "v{version}"
+  - path: ".github/workflows/release.yml"
+    replace:
+      - "ref: ${{ github.ref }} v{version}"
+      - "$$ ${version}"
+      - "${VB_TEST_TAG_PREFIX}{version}"
+`
+	if err := os.WriteFile(filePath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+
+	t.Setenv("VB_TEST_TAG_PREFIX", "release-")
+	t.Setenv("VERSIONBUMP_GIT_SIGN", "false")
+	t.Setenv("VERSIONBUMP_PRERELEASE_LABELS", "dev, rc")
+
+	config, _, err := LoadConfig(filePath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.GitTagTemplate != "release-{new}" {
+		t.Errorf("Expected git-tag-template 'release-{new}', but got '%s'", config.GitTagTemplate)
+	}
+	if config.Files[0].Path != "docs/README.md" {
+		t.Errorf("Expected path 'docs/README.md', but got '%s'", config.Files[0].Path)
+	}
+	// replace patterns only have their variable references expanded, so they still match the file contents
+	expected := []string{"ref: ${{ github.ref }} v{version}", "$$ ${version}", "release-{version}"}
+	if !reflect.DeepEqual(config.Files[1].Replace, expected) {
+		t.Errorf("Expected replace patterns %q, but got %q", expected, config.Files[1].Replace)
+	}
+	if config.GitSign {
+		t.Errorf("Expected git-sign to be overridden to false, but got true")
+	}
+	if len(config.PreReleaseLabels) != 2 || config.PreReleaseLabels[1] != "rc" {
+		t.Errorf("Expected pre-release labels [dev rc], but got %v", config.PreReleaseLabels)
+	}
+	if source := config.Source("git-sign"); source != "env:VERSIONBUMP_GIT_SIGN" {
+		t.Errorf("Expected the source of git-sign to be the environment, but got '%s'", source)
+	}
+
+	// overrides are validated like values from the file
+	t.Setenv("VERSIONBUMP_GIT_SIGN", "maybe")
+	if _, _, err := LoadConfig(filePath); err == nil {
+		t.Errorf("Expected an error for an invalid boolean override, but got none")
+	}
+	t.Setenv("VERSIONBUMP_GIT_SIGN", "0")
+	t.Setenv("VERSIONBUMP_PRERELEASE_LABELS", "rc1")
+	if _, _, err := LoadConfig(filePath); err == nil {
+		t.Errorf("Expected an error for invalid pre-release label overrides, but got none")
+	}
+}
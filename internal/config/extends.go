@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourceDefault is the source reported for configuration values that are not set in any configuration file.
+const SourceDefault = "(default)"
+
+// decodeConfig decodes a configuration file, merged with any configuration files it extends, into a Config.
+//
+// Extended files are merged in order, followed by the file itself, so later files override earlier ones:
+//   - Scalar values and `version-source` are replaced.
+//   - `prerelease-labels`, `prerelease-channels` and `strategies` are replaced as a whole, since their order is
+//     significant.
+//   - `files` entries are appended. An entry with the same `path` as an earlier entry replaces it.
+func decodeConfig(filePath string) (Config, error) {
+	var config Config
+	values, sources, err := loadConfigValues(filePath, nil)
+	if err != nil {
+		return config, err
+	}
+
+	// the merged values share the schema of the config, so re-decode them into the struct
+	b, err := yaml.Marshal(values)
+	if err != nil {
+		return config, err
+	}
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return config, err
+	}
+	config.Sources = sources
+	return config, nil
+}
+
+// loadConfigValues loads the raw values of a configuration file and the files it extends. It returns the merged values
+// and the file each value came from. `chain` holds the files extending this one, to detect cycles.
+func loadConfigValues(filePath string, chain []string) (map[string]interface{}, map[string]string, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range chain {
+		if p == absPath {
+			return nil, nil, fmt.Errorf("circular extends: %s", strings.Join(append(chain, absPath), " -> "))
+		}
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	node, err := parseConfigNode(filePath, content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	// unknown settings and invalid values are rejected, rather than silently ignored
+	v := &validator{file: filePath}
+	v.validateConfig(node)
+	if len(v.problems) > 0 {
+		return nil, nil, &ValidationError{Problems: v.problems}
+	}
+	values := make(map[string]interface{})
+	if err := node.Decode(&values); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	if err := interpolateValues(values); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+
+	extends, err := extendsList(values["extends"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+
+	merged := make(map[string]interface{})
+	sources := make(map[string]string)
+	for _, ext := range extends {
+		extPath := ResolvePath(filepath.Dir(filePath), ext)
+		base, baseSources, err := loadConfigValues(extPath, append(chain, absPath))
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := base["version"]; ok {
+			return nil, nil, fmt.Errorf("%s: the version cannot be set in an extended configuration file", extPath)
+		}
+		mergeConfigValues(merged, sources, base, baseSources)
+	}
+	mergeConfigValues(merged, sources, values, valueSources(values, filePath))
+
+	// only the extends of the loaded file itself are part of the effective configuration
+	delete(merged, "extends")
+	delete(sources, "extends")
+	if len(extends) > 0 {
+		merged["extends"] = extends
+		sources["extends"] = filePath
+	}
+	return merged, sources, nil
+}
+
+// extendsList normalizes the `extends` value, which is either a single path or a list of paths.
+func extendsList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		paths := make([]string, 0, len(v))
+		for _, p := range v {
+			s, ok := p.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid extends path: %v", p)
+			}
+			paths = append(paths, s)
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("extends must be a path or a list of paths")
+	}
+}
+
+// valueSources returns the sources of all values of a single configuration file.
+func valueSources(values map[string]interface{}, filePath string) map[string]string {
+	sources := make(map[string]string)
+	for key := range values {
+		sources[key] = filePath
+	}
+	files, _ := values["files"].([]interface{})
+	for _, file := range files {
+		sources[fileSourceKey(file)] = filePath
+	}
+	return sources
+}
+
+// mergeConfigValues merges the `src` values into `dst`, recording the source of each merged value.
+func mergeConfigValues(dst map[string]interface{}, dstSources map[string]string,
+	src map[string]interface{}, srcSources map[string]string) {
+	for key, value := range src {
+		if key == "files" {
+			dstFiles, _ := dst["files"].([]interface{})
+			srcFiles, _ := value.([]interface{})
+			for _, file := range srcFiles {
+				dstFiles = mergeFile(dstFiles, file)
+				dstSources[fileSourceKey(file)] = srcSources[fileSourceKey(file)]
+			}
+			dst["files"] = dstFiles
+		} else {
+			dst[key] = value
+		}
+		dstSources[key] = srcSources[key]
+	}
+}
+
+// mergeFile appends a `files` entry, replacing an existing entry with the same path.
+func mergeFile(files []interface{}, file interface{}) []interface{} {
+	for i, existing := range files {
+		if fileSourceKey(existing) == fileSourceKey(file) {
+			files[i] = file
+			return files
+		}
+	}
+	return append(files, file)
+}
+
+// fileSourceKey returns the key used to record the source of a `files` entry, e.g. `files[README.md]`.
+func fileSourceKey(file interface{}) string {
+	entry, _ := file.(map[string]interface{})
+	return fmt.Sprintf("files[%v]", entry["path"])
+}
+
+// Source returns the configuration file the value of the given key came from. Keys are the config keys (e.g.
+// `git-sign`), or `files[<path>]` for tracked files.
+func (v Config) Source(key string) string {
+	if source, ok := v.Sources[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+// SourceKeys returns the keys of the effective configuration, in the order they appear in the configuration.
+func (v Config) SourceKeys() []string {
+	keys := make([]string, 0)
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		if key == "files" {
+			for _, file := range v.Files {
+				keys = append(keys, fmt.Sprintf("files[%s]", file.Path))
+			}
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLoadConfigExtends tests merging a config file with the files it extends
+func TestLoadConfigExtends(t *testing.T) {
+	dir, err := os.MkdirTemp("", "loadConfigExtendsTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	orgDir := filepath.Join(dir, "org")
+	projectDir := filepath.Join(dir, "project")
+	for _, d := range []string{orgDir, projectDir} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+
+	files := map[string]string{
+		filepath.Join(orgDir, "base.yaml"): `
+git-commit: true
+git-sign: true
+git-tag-template: "release-{new}"
+prerelease-labels: ["dev", "preview", "rc"]
+files:
+  - path: "README.md"
+    replace: ["v{version}"]
+  - path: "CHANGELOG.md"
+    replace: ["## {version}"]
+`,
+		filepath.Join(orgDir, "signing.yaml"): `
+git-sign: false
+git-tag: true
+`,
+		filepath.Join(projectDir, "versionbump.yaml"): `
+extends:
+  - ../org/base.yaml
+  - ../org/signing.yaml
+version: "1.0.0"
+prerelease-labels: ["alpha", "beta"]
+files:
+  - path: "README.md"
+    replace: ["Version {version}"]
+  - path: "version.go"
+    replace: ["\"{version}\""]
+`,
+	}
+	for p, content := range files {
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+	}
+
+	configPath := filepath.Join(projectDir, "versionbump.yaml")
+	config, root, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if root != projectDir {
+		t.Errorf("Expected root directory '%s', but got '%s'", projectDir, root)
+	}
+	if !config.GitCommit || config.GitSign || !config.GitTag {
+		t.Errorf("Unexpected git settings: commit=%v sign=%v tag=%v", config.GitCommit, config.GitSign, config.GitTag)
+	}
+	if config.GitTagTemplate != "release-{new}" {
+		t.Errorf("Expected git-tag-template 'release-{new}', but got '%s'", config.GitTagTemplate)
+	}
+	if len(config.PreReleaseLabels) != 2 || config.PreReleaseLabels[0] != "alpha" {
+		t.Errorf("Expected the pre-release labels to be replaced, but got %v", config.PreReleaseLabels)
+	}
+
+	// README.md is overridden, CHANGELOG.md is inherited, version.go and the config file are added
+	expectedFiles := []string{"README.md", "CHANGELOG.md", "version.go", "versionbump.yaml"}
+	if len(config.Files) != len(expectedFiles) {
+		t.Fatalf("Expected %d files, but got %+v", len(expectedFiles), config.Files)
+	}
+	for i, expected := range expectedFiles {
+		if config.Files[i].Path != expected {
+			t.Errorf("Expected file %d to be '%s', but got '%s'", i, expected, config.Files[i].Path)
+		}
+	}
+	if config.Files[0].Replace[0] != "Version {version}" {
+		t.Errorf("Expected README.md replacements to be overridden, but got %v", config.Files[0].Replace)
+	}
+
+	sources := map[string]string{
+		"version":             configPath,
+		"git-commit":          filepath.Join(projectDir, "../org/base.yaml"),
+		"git-sign":            filepath.Join(projectDir, "../org/signing.yaml"),
+		"git-tag-template":    filepath.Join(projectDir, "../org/base.yaml"),
+		"build-label":         SourceDefault,
+		"files[CHANGELOG.md]": filepath.Join(projectDir, "../org/base.yaml"),
+		"files[README.md]":    configPath,
+	}
+	for key, expected := range sources {
+		if actual := config.Source(key); actual != expected {
+			t.Errorf("Expected the source of '%s' to be '%s', but got '%s'", key, expected, actual)
+		}
+	}
+}
+
+// TestLoadConfigExtendsErrors tests invalid extends configurations
+func TestLoadConfigExtendsErrors(t *testing.T) {
+	dir, err := os.MkdirTemp("", "loadConfigExtendsErrorsTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{"circular", map[string]string{
+			"versionbump.yaml": "extends: a.yaml\nversion: \"1.0.0\"\n",
+			"a.yaml":           "extends: b.yaml\n",
+			"b.yaml":           "extends: a.yaml\n",
+		}},
+		{"missing", map[string]string{
+			"versionbump.yaml": "extends: missing.yaml\nversion: \"1.0.0\"\n",
+		}},
+		{"version", map[string]string{
+			"versionbump.yaml": "extends: a.yaml\nversion: \"1.0.0\"\n",
+			"a.yaml":           "version: \"2.0.0\"\n",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testDir := filepath.Join(dir, test.name)
+			if err := os.MkdirAll(testDir, 0755); err != nil {
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+			for name, content := range test.files {
+				if err := os.WriteFile(filepath.Join(testDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write config file: %v", err)
+				}
+			}
+			if _, _, err := LoadConfig(filepath.Join(testDir, "versionbump.yaml")); err == nil {
+				t.Errorf("Expected an error, but got none")
+			}
+		})
+	}
+}
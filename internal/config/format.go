@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pelletier/go-toml/v2"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Format represents a configuration file format.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+	FormatTOML Format = "toml"
+)
+
+// Formats are the supported configuration file formats.
+var Formats = []Format{FormatYAML, FormatJSON, FormatTOML}
+
+func (f Format) String() string {
+	return string(f)
+}
+
+// ParseFormat returns the Format with the given name (e.g. "json").
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(name) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported configuration format: %s", name)
+}
+
+// FormatFromPath detects the configuration format from the file extension. Files without a known extension are
+// assumed to be YAML.
+func FormatFromPath(filePath string) Format {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return FormatJSON
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatYAML
+	}
+}
+
+// Extension returns the file extension for the format, without the leading dot.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// ConfigTemplate returns the `text/template` used to generate a new config file of this format.
+func (f Format) ConfigTemplate() string {
+	switch f {
+	case FormatJSON:
+		return DefaultJSONConfigTemplate
+	case FormatTOML:
+		return DefaultTOMLConfigTemplate
+	default:
+		return DefaultConfigTemplate
+	}
+}
+
+// RenderConfig renders the template of this format (see ConfigTemplate) for the given configuration.
+func (f Format) RenderConfig(w io.Writer, conf *Config) error {
+	tmpl, err := template.New(f.String()).Funcs(template.FuncMap{"quote": quoteString}).Parse(f.ConfigTemplate())
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, conf)
+}
+
+// quoteString quotes a string for the config templates. JSON string escapes are valid in YAML double-quoted strings
+// and TOML basic strings as well.
+func quoteString(s string) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Decode decodes the configuration data read from `r` into `v`. Decoding into a struct fails on unknown fields.
+func (f Format) Decode(r io.Reader, v interface{}) error {
+	switch f {
+	case FormatJSON:
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(v)
+	case FormatTOML:
+		decoder := toml.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(v)
+	default:
+		decoder := yaml.NewDecoder(r)
+		decoder.KnownFields(true)
+		return decoder.Decode(v)
+	}
+}
+
+// Marshal encodes `v` in this format.
+func (f Format) Marshal(v interface{}) ([]byte, error) {
+	switch f {
+	case FormatJSON:
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(v); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatTOML:
+		return toml.Marshal(v)
+	default:
+		return yamlv2.Marshal(v)
+	}
+}
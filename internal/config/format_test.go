@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestConfigTemplates tests that the generated config for each format can be loaded, and that the implicit
+// replacement pattern matches the version in the generated file
+func TestConfigTemplates(t *testing.T) {
+	dir, err := os.MkdirTemp("", "configTemplatesTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	conf := NewConfig()
+	conf.Version = "1.2.3"
+	conf.GitCommit = true
+	conf.GitCommitTemplate = `Bump version {{ .Old }} -> "{{ .New }}"`
+	conf.Files = []VersionedFile{{Path: "README.md", Replace: []string{"v{version}", "\"version\": \"{version}\""}}}
+
+	for _, format := range Formats {
+		t.Run(format.String(), func(t *testing.T) {
+			var sb strings.Builder
+			if err := format.RenderConfig(&sb, conf); err != nil {
+				t.Fatalf("Failed to render template: %v", err)
+			}
+			filePath := filepath.Join(dir, "versionbump."+format.Extension())
+			if err := os.WriteFile(filePath, []byte(sb.String()), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+
+			loaded, _, err := LoadConfig(filePath)
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v\n%s", err, sb.String())
+			}
+			if loaded.Version != "1.2.3" {
+				t.Errorf("Expected version '1.2.3', but got '%s'", loaded.Version)
+			}
+			if !loaded.GitCommit {
+				t.Errorf("Expected git-commit to be true, but got false")
+			}
+			// templates are quoted, so they can contain quotes themselves
+			if loaded.GitCommitTemplate != conf.GitCommitTemplate {
+				t.Errorf("Expected git-commit-template '%s', but got '%s'", conf.GitCommitTemplate,
+					loaded.GitCommitTemplate)
+			}
+			if len(loaded.PreReleaseLabels) != 3 || loaded.PreReleaseLabels[2] != "rc" {
+				t.Errorf("Unexpected pre-release labels: %v", loaded.PreReleaseLabels)
+			}
+			if len(loaded.Files) != 2 || len(loaded.Files[0].Replace) != 2 {
+				t.Fatalf("Unexpected files: %+v", loaded.Files)
+			}
+			// patterns are quoted, so they can contain quotes themselves
+			if loaded.Files[0].Replace[1] != conf.Files[0].Replace[1] {
+				t.Errorf("Expected pattern '%s', but got '%s'", conf.Files[0].Replace[1], loaded.Files[0].Replace[1])
+			}
+
+			// the config file updates its own version in place
+			self := loaded.Files[1]
+			if self.Key != "version" {
+				t.Fatalf("Expected the config file to be tracked by its version key, but got %+v", self)
+			}
+			if err := WriteKey(filePath, self.Key, "1.3.0"); err != nil {
+				t.Fatalf("WriteKey failed: %v", err)
+			}
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read config file: %v", err)
+			}
+			if expected := strings.Replace(sb.String(), "1.2.3", "1.3.0", 1); string(content) != expected {
+				t.Errorf("Expected only the version to change, but got:\n%s", string(content))
+			}
+
+			// the effective config can be written in the same format and loaded again
+			b, err := format.Marshal(loaded)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var decoded Config
+			if err := format.Decode(strings.NewReader(string(b)), &decoded); err != nil {
+				t.Fatalf("Decode failed: %v\n%s", err, string(b))
+			}
+			if decoded.Version != "1.2.3" || len(decoded.Files) != 2 {
+				t.Errorf("Unexpected round trip result: %+v", decoded)
+			}
+		})
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected Format
+	}{
+		{"versionbump.yaml", FormatYAML},
+		{".versionbump.yml", FormatYAML},
+		{"config/versionbump.JSON", FormatJSON},
+		{"versionbump.toml", FormatTOML},
+		{"versionbump", FormatYAML},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if actual := FormatFromPath(test.path); actual != test.expected {
+				t.Errorf("Expected %s, but got %s", test.expected, actual)
+			}
+		})
+	}
+}
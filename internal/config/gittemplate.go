@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// gitTemplateFuncs are the helper functions available to git templates.
+var gitTemplateFuncs = template.FuncMap{
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"trim":    strings.TrimSpace,
+	"replace": strings.ReplaceAll,
+	"join": func(sep string, elems []string) string {
+		return strings.Join(elems, sep)
+	},
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	"pre": preRelease,
+}
+
+// legacyPlaceholders maps the original brace placeholders to their Go template equivalents.
+var legacyPlaceholders = strings.NewReplacer(
+	"{old}", "{{.Old}}",
+	"{new}", "{{.New}}",
+)
+
+// ParseGitTemplate parses a git template (e.g. `git-tag-template`). Templates use Go `text/template` syntax, but the
+// legacy `{old}` and `{new}` placeholders are still supported.
+func ParseGitTemplate(name string, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(gitTemplateFuncs).Parse(legacyPlaceholders.Replace(text))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// newVersionAction matches the Go template action that renders the new version (e.g. `{{ .New }}`).
+var newVersionAction = regexp.MustCompile(`\{\{-?\s*\.New\s*-?\}\}`)
+
+// SplitTagTemplate returns the literal text before and after the new version in a git tag template, which versions are
+// extracted from tags with. The template must reference the new version exactly once, with either the `{new}`
+// placeholder or a bare `{{.New}}` action, and can't contain other template actions.
+func SplitTagTemplate(text string) (string, string, error) {
+	const placeholder = "{new}"
+	normalized := newVersionAction.ReplaceAllString(text, placeholder)
+	prefix, suffix, _ := strings.Cut(normalized, placeholder)
+	switch {
+	case strings.Contains(prefix+suffix, "{{"):
+		return "", "", fmt.Errorf("must reference the new version with {new} or a bare {{.New}}, other template " +
+			"actions can't be used to extract versions from tags")
+	case !strings.Contains(normalized, placeholder):
+		return "", "", fmt.Errorf("must contain the new version ({new} or {{.New}})")
+	case strings.Contains(suffix, placeholder):
+		return "", "", fmt.Errorf("must contain the new version ({new} or {{.New}}) only once")
+	}
+	return prefix, suffix, nil
+}
+
+// preRelease appends pre-release identifiers to a version, without its build metadata. A pre-release version is
+// extended, so the result still precedes the next pre-release, e.g. 1.2.3 -> 1.2.3-dev.7 and 1.2.3-rc.1 ->
+// 1.2.3-rc.1.dev.7.
+func preRelease(v *semver.SemanticVersion, identifiers ...interface{}) string {
+	ids := make([]string, 0, len(identifiers))
+	for _, id := range identifiers {
+		ids = append(ids, fmt.Sprint(id))
+	}
+	separator := "-"
+	if v.IsPreRelease() {
+		separator = "."
+	}
+	return v.WithoutBuild().String() + separator + strings.Join(ids, ".")
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// SchemaURL is the location the JSON Schema of the configuration file is published at.
+const SchemaURL = "https://raw.githubusercontent.com/ptgoetz/go-versionbump/main/versionbump.schema.json"
+
+// settingDescriptions describe the configuration settings in the JSON Schema. Nested settings are keyed by their
+// dotted path (e.g. `files.path`).
+var settingDescriptions = map[string]string{
+	"extends":                   "Configuration files to inherit settings from, relative to this file.",
+	"version":                   "The current version of the project.",
+	"version-scheme":            "The format of the version: semver, or four-part for major.minor.patch.revision versions.",
+	"build-label":               "The label used for build versions.",
+	"prerelease-labels":         "The pre-release labels, in the order versions progress through them.",
+	"prerelease-label-order":    "How pre-release labels are ordered: as listed in prerelease-labels, or lexically.",
+	"prerelease-channels":       "Named pre-release channels, each numbered independently with its own label.",
+	"prerelease-channels.name":  "The name of the channel, e.g. nightly.",
+	"prerelease-channels.label": "The pre-release label of the channel, defaults to the name.",
+	"strategies":                "Custom bump strategies, each applying other strategies in order.",
+	"strategies.name":           "The name of the strategy, used as the command name, e.g. finalize.",
+	"strategies.description":    "The description of the strategy, shown in the help.",
+	"strategies.steps":          "The strategies to apply, in order: built-in strategies or strategies defined before this one.",
+	"build-metadata":            "Build metadata providers used by pre-build instead of the build label and number: sha, timestamp, date, commits, dirty (which allows uncommitted changes), ci or env:NAME.",
+	"git-commit":                "Whether to create a git commit for the version bump.",
+	"git-commit-template":       "The template for the git commit message.",
+	"git-sign":                  "Whether to sign the git commit and tag.",
+	"git-tag":                   "Whether to create a git tag for the version bump.",
+	"git-tag-template":          "The template for the git tag name.",
+	"git-tag-message-template":  "The template for the git tag message.",
+	"version-policy":            "Whether new versions that are already tagged or lower than the latest tagged version on the same release line are refused (strict), reported as warnings (warn) or allowed (off).",
+	"describe-template":         "The template for the versions of the describe command.",
+	"files":                     "The files to update with the new version.",
+	"files.path":                "The path of the file, relative to the configuration file.",
+	"files.replace":             "Search strings with a {version} placeholder to replace.",
+	"files.key":                 "A dotted key locating the version in a JSON or YAML file.",
+	"files.format":              "The version dialect of the file: semver, pep440, npm, maven or nuget.",
+	"version-source":            "Read the version from another source instead of the version setting.",
+	"version-source.file":       "The file containing the version.",
+	"version-source.pattern":    "A pattern with a {version} placeholder locating the version.",
+	"version-source.key":        "A dotted key locating the version in a JSON or YAML file.",
+	"version-source.latest-tag": "Derive the version from the latest git tag.",
+}
+
+// settingValues are the allowed values of settings with a fixed set of values.
+var settingValues = map[string][]string{
+	"version-scheme":         {string(semver.SchemeSemVer), string(semver.SchemeFourPart)},
+	"prerelease-label-order": {LabelOrderConfig, LabelOrderLexical},
+	"version-policy":         {VersionPolicyStrict, VersionPolicyWarn, VersionPolicyOff},
+	"files.format": {string(semver.DialectSemVer), string(semver.DialectPEP440), string(semver.DialectNpm),
+		string(semver.DialectMaven), string(semver.DialectNuGet)},
+}
+
+// isSettingValue returns true if the value is one of the allowed values of the setting.
+func isSettingValue(key string, value string) bool {
+	for _, allowed := range settingValues[key] {
+		if value == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema returns the JSON Schema of the configuration file, generated from Config.
+func Schema() map[string]interface{} {
+	schema := structSchema(reflect.TypeOf(Config{}), "")
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["$id"] = SchemaURL
+	schema["title"] = "VersionBump configuration"
+	schema["properties"].(map[string]interface{})["$schema"] = map[string]interface{}{"type": "string"}
+	return schema
+}
+
+// structSchema returns the schema of an object with the fields of the given struct type.
+func structSchema(t reflect.Type, prefix string) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		property := typeSchema(t.Field(i).Type, prefix+key)
+		if description, ok := settingDescriptions[prefix+key]; ok {
+			property["description"] = description
+		}
+		if values, ok := settingValues[prefix+key]; ok {
+			property["enum"] = values
+		}
+		properties[key] = property
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// typeSchema returns the schema of a value of the given type.
+func typeSchema(t reflect.Type, name string) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return structSchema(t, name+".")
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Slice:
+		items := typeSchema(t.Elem(), name)
+		if name == "extends" {
+			// a single path is allowed as well
+			return map[string]interface{}{"type": []string{"string", "array"}, "items": items}
+		}
+		return map[string]interface{}{"type": "array", "items": items}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
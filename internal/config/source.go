@@ -0,0 +1,260 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// versionPlaceholder is the placeholder for the version in replacement patterns.
+const versionPlaceholder = "{version}"
+
+// loadVersionSource reads the project version from the configured version source and registers the source as a
+// tracked file so the new version is written back to it.
+func (v *Config) loadVersionSource(root string) error {
+	src := v.VersionSource
+	if src.LatestTag {
+		if src.File != "" || src.Key != "" || src.Pattern != "" {
+			return fmt.Errorf("version-source latest-tag cannot be combined with file, key or pattern")
+		}
+		// the latest tag is the version, so bumping always creates a tag
+		v.GitTag = true
+		return nil
+	}
+
+	if src.File == "" {
+		return fmt.Errorf("version-source requires a file or latest-tag")
+	}
+	if (src.Key == "") == (src.Pattern == "") {
+		return fmt.Errorf("version-source requires exactly one of key or pattern")
+	}
+
+	filePath := ResolvePath(root, src.File)
+	var err error
+	if src.Key != "" {
+		v.Version, err = ReadKey(filePath, src.Key)
+		v.Files = append(v.Files, VersionedFile{Path: src.File, Key: src.Key})
+	} else {
+		v.Version, err = ReadPattern(filePath, src.Pattern)
+		v.Files = append(v.Files, VersionedFile{Path: src.File, Replace: []string{src.Pattern}})
+	}
+	if err != nil {
+		return fmt.Errorf("error reading version from version-source: %w", err)
+	}
+	return nil
+}
+
+// ResolvePath resolves a tracked file path. Relative paths are relative to the project root directory and absolute
+// paths are used as-is.
+func ResolvePath(root string, filePath string) string {
+	if filepath.IsAbs(filePath) {
+		return filePath
+	}
+	return filepath.Join(root, filePath)
+}
+
+// ReadPattern returns the version matched by the first occurrence of `pattern` in the file at the given path.
+// The pattern must contain a `{version}` placeholder.
+func ReadPattern(filePath string, pattern string) (string, error) {
+	if !strings.Contains(pattern, versionPlaceholder) {
+		return "", fmt.Errorf("pattern '%s' does not contain a %s placeholder", pattern, versionPlaceholder)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	expr := strings.Replace(regexp.QuoteMeta(pattern), regexp.QuoteMeta(versionPlaceholder), `([0-9A-Za-z.+\-]+)`, 1)
+	expr = strings.ReplaceAll(expr, regexp.QuoteMeta(versionPlaceholder), `[0-9A-Za-z.+\-]+`)
+	match := regexp.MustCompile(expr).FindSubmatch(content)
+	if match == nil {
+		return "", fmt.Errorf("pattern '%s' not found in file: %s", pattern, filePath)
+	}
+	return string(match[1]), nil
+}
+
+// ReadKey returns the scalar value of a dotted key (e.g. `metadata.version`) in a YAML, JSON or TOML file.
+func ReadKey(filePath string, key string) (string, error) {
+	_, node, err := findKey(filePath, key)
+	if err != nil {
+		return "", err
+	}
+	return node.Value, nil
+}
+
+// WriteKey sets the scalar value of a dotted key in a YAML, JSON or TOML file. Only the value itself is rewritten, so
+// comments, formatting and the quoting style of the value are preserved.
+func WriteKey(filePath string, key string, value string) error {
+	content, node, err := findKey(filePath, key)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if node.Line < 1 || node.Line > len(lines) {
+		return fmt.Errorf("invalid position for key '%s' in file: %s", key, filePath)
+	}
+	line := lines[node.Line-1]
+
+	// yaml columns are counted in characters, convert to a byte offset
+	start := 0
+	for i := 1; i < node.Column && start < len(line); i++ {
+		_, size := utf8.DecodeRuneInString(line[start:])
+		start += size
+	}
+
+	var end int
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		end = closingQuote(line, start, '"')
+	case yaml.SingleQuotedStyle:
+		end = closingQuote(line, start, '\'')
+	default:
+		end = start + len(node.Value)
+	}
+	if end < 0 || end > len(line) || !strings.Contains(line[start:end], node.Value) {
+		return fmt.Errorf("unable to locate the value of key '%s' in file: %s", key, filePath)
+	}
+	replacement, err := formatScalar(FormatFromPath(filePath), node.Style, value)
+	if err != nil {
+		return err
+	}
+	lines[node.Line-1] = line[:start] + replacement + line[end:]
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, []byte(strings.Join(lines, "")), info.Mode())
+}
+
+// formatScalar formats a value to replace a scalar of the given style. Quoted values keep their quotes. Unquoted
+// values stay unquoted only if the value is read back unchanged, and, in JSON and TOML, isn't a string.
+func formatScalar(format Format, style yaml.Style, value string) (string, error) {
+	switch style {
+	case yaml.DoubleQuotedStyle:
+		return quoteString(value)
+	case yaml.SingleQuotedStyle:
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'", nil
+	}
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(value), &parsed); err == nil && parsed != nil && fmt.Sprint(parsed) == value {
+		if _, isString := parsed.(string); !isString || format == FormatYAML {
+			return value, nil
+		}
+	}
+	return quoteString(value)
+}
+
+// findKey parses a YAML, JSON or TOML file and returns its content and the scalar node of the given dotted key.
+func findKey(filePath string, key string) ([]byte, *yaml.Node, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	node, err := parseConfigNode(filePath, content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing file %s: %w", filePath, err)
+	}
+	if node.Kind == yaml.MappingNode && len(node.Content) == 0 {
+		return nil, nil, fmt.Errorf("key '%s' not found in empty file: %s", key, filePath)
+	}
+
+	for _, part := range strings.Split(key, ".") {
+		node = mappingValue(node, part)
+		if node == nil {
+			return nil, nil, fmt.Errorf("key '%s' not found in file: %s", key, filePath)
+		}
+	}
+	if node.Kind != yaml.ScalarNode {
+		return nil, nil, fmt.Errorf("key '%s' is not a scalar value in file: %s", key, filePath)
+	}
+	if FormatFromPath(filePath) == FormatTOML {
+		if err := locateTOMLValue(node, content); err != nil {
+			return nil, nil, fmt.Errorf("key '%s' %w in file: %s", key, err, filePath)
+		}
+	}
+	return content, node, nil
+}
+
+// locateTOMLValue moves a scalar node decoded from TOML, positioned at its key, to the value following the `=`, and
+// sets its style from the quotes of the value. The value found there must decode to the value of the node, so that a
+// mislocated key is never rewritten.
+func locateTOMLValue(node *yaml.Node, content []byte) error {
+	lines := strings.Split(string(content), "\n")
+	if node.Line < 1 || node.Line > len(lines) {
+		return fmt.Errorf("could not be located")
+	}
+	line := lines[node.Line-1]
+	eq := strings.IndexByte(line[node.Column-1:], '=')
+	if eq < 0 {
+		return fmt.Errorf("could not be located")
+	}
+	start := node.Column - 1 + eq + 1
+	for start < len(line) && (line[start] == ' ' || line[start] == '\t') {
+		start++
+	}
+	if start >= len(line) || strings.HasPrefix(line[start:], `"""`) || strings.HasPrefix(line[start:], "'''") {
+		return fmt.Errorf("is not a single-line value")
+	}
+	var located string
+	switch line[start] {
+	case '"', '\'':
+		node.Style = yaml.DoubleQuotedStyle
+		if line[start] == '\'' {
+			node.Style = yaml.SingleQuotedStyle
+		}
+		end := closingTOMLQuote(line, start)
+		if end < 0 {
+			return fmt.Errorf("could not be located")
+		}
+		var decoded struct{ Value string }
+		if err := toml.Unmarshal([]byte("Value = "+line[start:end]), &decoded); err != nil {
+			return fmt.Errorf("could not be located: %w", err)
+		}
+		located = decoded.Value
+	default:
+		node.Style = 0
+		located = line[start:min(start+len(node.Value), len(line))]
+	}
+	if located != node.Value {
+		return fmt.Errorf("could not be located, line %d has the value '%s' instead of '%s'", node.Line, located,
+			node.Value)
+	}
+	// the columns of the node are counted in characters
+	node.Column = utf8.RuneCountInString(line[:start]) + 1
+	return nil
+}
+
+// mappingValue returns the value node for the given key in a mapping node, or nil if it doesn't exist.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// closingQuote returns the offset just past the quote closing the quoted string starting at `start`, or -1.
+func closingQuote(line string, start int, quote byte) int {
+	for i := start + 1; i < len(line); i++ {
+		switch {
+		case quote == '"' && line[i] == '\\':
+			i++
+		case line[i] == quote && quote == '\'' && i+1 < len(line) && line[i+1] == '\'':
+			i++
+		case line[i] == quote:
+			return i + 1
+		}
+	}
+	return -1
+}
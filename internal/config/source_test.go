@@ -0,0 +1,185 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestReadPattern tests the ReadPattern function
+func TestReadPattern(t *testing.T) {
+	dir, err := os.MkdirTemp("", "readPatternTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "version.go")
+	content := "package main\n\nconst Version = \"1.2.3-alpha.1\"\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	version, err := ReadPattern(filePath, "const Version = \"{version}\"")
+	if err != nil {
+		t.Fatalf("ReadPattern failed: %v", err)
+	}
+	if version != "1.2.3-alpha.1" {
+		t.Errorf("Expected version '1.2.3-alpha.1', but got '%s'", version)
+	}
+
+	if _, err := ReadPattern(filePath, "var Version = \"{version}\""); err == nil {
+		t.Errorf("Expected an error for a pattern that doesn't match, but got none")
+	}
+	if _, err := ReadPattern(filePath, "const Version"); err == nil {
+		t.Errorf("Expected an error for a pattern without a placeholder, but got none")
+	}
+}
+
+// TestReadWriteKey tests that keys are updated in place, preserving comments and quoting
+func TestReadWriteKey(t *testing.T) {
+	dir, err := os.MkdirTemp("", "readWriteKeyTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name     string
+		key      string
+		content  string
+		expected string
+	}{
+		{
+			"package.json",
+			"version",
+			"{\n  \"name\": \"app\",\n  \"version\": \"1.2.3\",\n  \"dependencies\": {\"lib\": \"1.2.3\"}\n}\n",
+			"{\n  \"name\": \"app\",\n  \"version\": \"1.3.0\",\n  \"dependencies\": {\"lib\": \"1.2.3\"}\n}\n",
+		},
+		{
+			"Chart.yaml",
+			"version",
+			"# chart\nname: app\nversion: 1.2.3 # the chart version\nappVersion: \"1.2.3\"\n",
+			"# chart\nname: app\nversion: 1.3.0 # the chart version\nappVersion: \"1.2.3\"\n",
+		},
+		{
+			"Cargo.toml",
+			"package.version",
+			"# crate\n[package]\nname = \"app\"\nversion = \"1.2.3\" # the crate version\n\n[dependencies]\nlib = \"1.2.3\"\n",
+			"# crate\n[package]\nname = \"app\"\nversion = \"1.3.0\" # the crate version\n\n[dependencies]\nlib = \"1.2.3\"\n",
+		},
+		{
+			"pyproject.toml",
+			"project.version",
+			"# Set version = \"x\" below\n[tool.docs]\ntitle = \"version = 0.1\"\nnotes = \"\"\"\nversion = \"0.2\"\n\"\"\"\n\n[project]\nname = \"app\" # version = \"0.3\"\nversion = \"1.2.3\"\n",
+			"# Set version = \"x\" below\n[tool.docs]\ntitle = \"version = 0.1\"\nnotes = \"\"\"\nversion = \"0.2\"\n\"\"\"\n\n[project]\nname = \"app\" # version = \"0.3\"\nversion = \"1.3.0\"\n",
+		},
+		{
+			"versionbump.toml",
+			"version",
+			"version = '1.2.3'\ngit-tag = true\n",
+			"version = '1.3.0'\ngit-tag = true\n",
+		},
+		{
+			"nested.yaml",
+			"metadata.version",
+			"metadata:\n  version: '1.2.3'\n",
+			"metadata:\n  version: '1.3.0'\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filePath := filepath.Join(dir, test.name)
+			if err := os.WriteFile(filePath, []byte(test.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			value, err := ReadKey(filePath, test.key)
+			if err != nil {
+				t.Fatalf("ReadKey failed: %v", err)
+			}
+			if value != "1.2.3" {
+				t.Errorf("Expected value '1.2.3', but got '%s'", value)
+			}
+
+			if err := WriteKey(filePath, test.key, "1.3.0"); err != nil {
+				t.Fatalf("WriteKey failed: %v", err)
+			}
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read test file: %v", err)
+			}
+			if string(content) != test.expected {
+				t.Errorf("Expected content %q, but got %q", test.expected, string(content))
+			}
+		})
+	}
+
+	if _, err := ReadKey(filepath.Join(dir, "Chart.yaml"), "missing"); err == nil {
+		t.Errorf("Expected an error for a missing key, but got none")
+	}
+
+	// a value that doesn't match the decoded value is never rewritten
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: "1.2.3", Line: 1, Column: 1}
+	if err := locateTOMLValue(node, []byte("version = \"1.2.4\"\n")); err == nil {
+		t.Errorf("Expected an error for a mislocated value, but got none")
+	}
+}
+
+// TestLoadConfigVersionSource tests loading the version from a version source
+func TestLoadConfigVersionSource(t *testing.T) {
+	dir, err := os.MkdirTemp("", "loadConfigVersionSourceTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("2.0.1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write VERSION file: %v", err)
+	}
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	yamlContent := `
+version-source:
+  file: "VERSION"
+  pattern: "{version}"
+files:
+  - path: "README.md"
+    replace:
+      - "v{version}"
+`
+	if err := os.WriteFile(filePath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+
+	config, _, err := LoadConfig(filePath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.Version != "2.0.1" {
+		t.Errorf("Expected version '2.0.1', but got '%s'", config.Version)
+	}
+	// the version source replaces the config file as a tracked file
+	if len(config.Files) != 2 {
+		t.Fatalf("Expected 2 files, but got %d", len(config.Files))
+	}
+	if config.Files[1].Path != "VERSION" || config.Files[1].Replace[0] != "{version}" {
+		t.Errorf("Unexpected file config for 'VERSION': %+v", config.Files[1])
+	}
+
+	invalidContent := `
+version: "1.0.0"
+version-source:
+  file: "VERSION"
+  pattern: "{version}"
+`
+	if err := os.WriteFile(filePath, []byte(invalidContent), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+	if _, _, err := LoadConfig(filePath); err == nil {
+		t.Errorf("Expected an error when both version and version-source are set, but got none")
+	}
+}
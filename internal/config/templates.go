@@ -1,8 +1,10 @@
 package config
 
-const DefaultConfigTemplate = `# The current version of the project. This is the source of truth for the project version. 
+const DefaultConfigTemplate = `# yaml-language-server: $schema=https://raw.githubusercontent.com/ptgoetz/go-versionbump/main/versionbump.schema.json
+
+# The current version of the project. This is the source of truth for the project version. 
 # Set this once and let VersionBump manage it.
-version: "{{.Version}}" 
+version: {{ quote .Version }} 
 
 # Git configuration (optional)
 git-commit: {{ .GitCommit }} # Whether to create a git commit for the version bump.
@@ -10,21 +12,21 @@ git-sign: {{ .GitSign }}   # Whether to sign the git commit and tag.
 git-tag: {{ .GitTag }}    # Whether to create a git tag for the version bump.
 
 # Git commit and tag templates. These are the templates used for the git commit and tag messages.
-git-commit-template: "{{ .GitCommitTemplate }}" # The template for the git commit message.
-git-tag-template: "{{ .GitTagTemplate }}" # The template for the git tag name.
-git-tag-message-template: "{{ .GitTagMessageTemplate }}" # The template for the git tag message.
+git-commit-template: {{ quote .GitCommitTemplate }} # The template for the git commit message.
+git-tag-template: {{ quote .GitTagTemplate }} # The template for the git tag name.
+git-tag-message-template: {{ quote .GitTagMessageTemplate }} # The template for the git tag message.
 
 # Prerelease labels. These are the labels that will be used for prerelease versions.
-# VersionBump with sort these labels in ascending order when determining the next version.
+# Versions progress through the labels in the order they are listed (e.g. dev -> preview -> rc).
 # If the bump type is 'prerelease-next'', the next label will be used. Attempting to bump past the last label 
 # will result in an error.
 prerelease-labels:
 {{- range .PreReleaseLabels }}
-  - "{{ . }}"
+  - {{ quote . }}
 {{- end }}
 
 # The build label. This is the label that will be used for build versions.
-build-label: "{{.BuildLabel}}"
+build-label: {{ quote .BuildLabel }}
 
 files:  # The files to update with the new version (i.e. "Tracked files").
 # The following example will replace all occurrences of the old version with the new version in the README.md file.
@@ -32,10 +34,77 @@ files:  # The files to update with the new version (i.e. "Tracked files").
 #    replace:
 #      - "v{version}"
 {{- range .Files }}
-  - path: "{{ .Path }}"
+  - path: {{ quote .Path }}
     replace:
     {{- range .Replace }}
-      - "{{ . }}"
+      - {{ quote . }}
     {{- end }}
 {{- end }}
 `
+
+const DefaultJSONConfigTemplate = `{
+  "version": {{ quote .Version }},
+  "git-commit": {{ .GitCommit }},
+  "git-sign": {{ .GitSign }},
+  "git-tag": {{ .GitTag }},
+  "git-commit-template": {{ quote .GitCommitTemplate }},
+  "git-tag-template": {{ quote .GitTagTemplate }},
+  "git-tag-message-template": {{ quote .GitTagMessageTemplate }},
+  "prerelease-labels": [
+{{- range $i, $label := .PreReleaseLabels }}{{ if $i }},{{ end }}
+    {{ quote $label }}
+{{- end }}
+  ],
+  "build-label": {{ quote .BuildLabel }},
+  "files": [
+{{- range $i, $file := .Files }}{{ if $i }},{{ end }}
+    {
+      "path": {{ quote $file.Path }},
+      "replace": [
+{{- range $j, $replace := $file.Replace }}{{ if $j }},{{ end }}
+        {{ quote $replace }}
+{{- end }}
+      ]
+    }
+{{- end }}
+  ]
+}
+`
+
+const DefaultTOMLConfigTemplate = `# The current version of the project. This is the source of truth for the project version. 
+# Set this once and let VersionBump manage it.
+version = {{ quote .Version }}
+
+# Git configuration (optional)
+git-commit = {{ .GitCommit }} # Whether to create a git commit for the version bump.
+git-sign = {{ .GitSign }}   # Whether to sign the git commit and tag.
+git-tag = {{ .GitTag }}    # Whether to create a git tag for the version bump.
+
+# Git commit and tag templates. These are the templates used for the git commit and tag messages.
+git-commit-template = {{ quote .GitCommitTemplate }} # The template for the git commit message.
+git-tag-template = {{ quote .GitTagTemplate }} # The template for the git tag name.
+git-tag-message-template = {{ quote .GitTagMessageTemplate }} # The template for the git tag message.
+
+# Prerelease labels. These are the labels that will be used for prerelease versions.
+# Versions progress through the labels in the order they are listed (e.g. dev -> preview -> rc).
+# If the bump type is 'prerelease-next'', the next label will be used. Attempting to bump past the last label 
+# will result in an error.
+prerelease-labels = [
+{{- range $i, $label := .PreReleaseLabels }}{{ if $i }},{{ end }} {{ quote $label }}{{ end }} ]
+
+# The build label. This is the label that will be used for build versions.
+build-label = {{ quote .BuildLabel }}
+
+# The files to update with the new version (i.e. "Tracked files").
+# The following example will replace all occurrences of the old version with the new version in the README.md file.
+# [[files]]
+# path = "README.md"
+# replace = [ "v{version}" ]
+{{- range .Files }}
+
+[[files]]
+path = {{ quote .Path }}
+replace = [
+{{- range $i, $replace := .Replace }}{{ if $i }},{{ end }} {{ quote $replace }}{{ end }} ]
+{{- end }}
+`
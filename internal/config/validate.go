@@ -0,0 +1,532 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/ptgoetz/go-versionbump/internal/utils"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a problem found in a configuration file. Line and Column are 1-based, and 0 if the position is unknown.
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.File, p.Message)
+}
+
+// ValidationError is returned when a configuration file has problems.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		messages[i] = p.String()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks a configuration file and the files it extends, and returns every problem found. Unlike LoadConfig,
+// it doesn't stop at the first invalid file, and it also checks that the tracked files exist.
+func Validate(filePath string) []Problem {
+	root := filepath.Dir(filePath)
+	problems := validateFile(filePath, root, make(map[string]bool))
+	if len(problems) > 0 {
+		return problems
+	}
+
+	// the checks spanning the merged configuration (e.g. version and version-source) are done when loading it
+	if _, _, err := LoadConfig(filePath); err != nil {
+		problems = append(problems, Problem{File: filePath, Message: err.Error()})
+	}
+	return problems
+}
+
+// validateFile validates a single configuration file, followed by the files it extends.
+func validateFile(filePath string, root string, visited map[string]bool) []Problem {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil || visited[absPath] {
+		// cycles are reported when loading the configuration
+		return nil
+	}
+	visited[absPath] = true
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return []Problem{{File: filePath, Message: err.Error()}}
+	}
+	node, err := parseConfigNode(filePath, content)
+	if err != nil {
+		return []Problem{{File: filePath, Message: err.Error()}}
+	}
+	v := &validator{file: filePath, root: root}
+	v.validateConfig(node)
+
+	problems := v.problems
+	var values map[string]interface{}
+	if err := node.Decode(&values); err == nil {
+		extends, _ := extendsList(values["extends"])
+		for _, ext := range extends {
+			if ext, err = InterpolateEnv(ext); err == nil {
+				problems = append(problems, validateFile(ResolvePath(filepath.Dir(filePath), ext), root, visited)...)
+			}
+		}
+	}
+	return problems
+}
+
+// parseConfigNode parses the content of a configuration file into a YAML node tree. YAML and JSON keep the position
+// of each node. TOML is decoded first, and the positions of the keys are then located in the content.
+func parseConfigNode(filePath string, content []byte) (*yaml.Node, error) {
+	if FormatFromPath(filePath) == FormatTOML {
+		values := make(map[string]interface{})
+		if err := toml.Unmarshal(content, &values); err != nil {
+			return nil, err
+		}
+		var node yaml.Node
+		if err := node.Encode(values); err != nil {
+			return nil, err
+		}
+		locateTOMLKeys(&node, maskTOML(strings.Split(string(content), "\n")), 0)
+		return &node, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		// an empty file is an empty configuration
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+	return doc.Content[0], nil
+}
+
+// locateTOMLKeys sets the positions of the mapping keys in a node tree decoded from TOML, by searching the lines of
+// the document from line index `from` onwards. Keys of nested tables are searched from the line of their parent.
+func locateTOMLKeys(node *yaml.Node, lines []string, from int) int {
+	last := from
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			pattern := regexp.MustCompile(`(^|[\s{,.\[])"?` + regexp.QuoteMeta(key.Value) + `"?\s*(=|\])`)
+			for l := from; l < len(lines); l++ {
+				if loc := pattern.FindStringIndex(lines[l]); loc != nil {
+					key.Line = l + 1
+					key.Column = strings.Index(lines[l][loc[0]:], key.Value) + loc[0] + 1
+					value.Line, value.Column = key.Line, key.Column
+					if l > last {
+						last = l
+					}
+					if end := locateTOMLKeys(value, lines, l); end > last {
+						last = end
+					}
+					break
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		// entries are in document order, so each entry is searched for after the previous one
+		next := from
+		for _, item := range node.Content {
+			item.Line, item.Column = next+1, 1
+			end := locateTOMLKeys(item, lines, next)
+			if end > last {
+				last = end
+			}
+			if item.Kind == yaml.MappingNode && end >= next {
+				next = end + 1
+			}
+		}
+	}
+	return last
+}
+
+// maskTOML blanks out the comments and the string values of TOML lines, so that text in them isn't mistaken for a key.
+// Quoted keys, i.e. strings followed by `=` or `.`, or in a table header, are kept. Every blanked byte is replaced by a
+// space, so offsets in the masked lines are offsets in the original lines.
+func maskTOML(lines []string) []string {
+	masked := make([]string, len(lines))
+	multiline := ""
+	for l, line := range lines {
+		b := []byte(line)
+		header := strings.HasPrefix(strings.TrimSpace(line), "[")
+		for i := 0; i < len(b); i++ {
+			if multiline != "" {
+				// the content of a multi-line string is always a value
+				if strings.HasPrefix(line[i:], multiline) {
+					i += len(multiline) - 1
+					multiline = ""
+				} else {
+					if multiline == `"""` && b[i] == '\\' && i+1 < len(b) {
+						b[i] = ' '
+						i++
+					}
+					b[i] = ' '
+				}
+				continue
+			}
+			switch {
+			case b[i] == '#':
+				for ; i < len(b); i++ {
+					b[i] = ' '
+				}
+			case strings.HasPrefix(line[i:], `"""`) || strings.HasPrefix(line[i:], "'''"):
+				multiline = line[i : i+3]
+				i += 2
+			case b[i] == '"' || b[i] == '\'':
+				end := closingTOMLQuote(line, i)
+				if end < 0 {
+					end = len(line)
+				}
+				next := strings.TrimLeft(line[end:], " \t")
+				isKey := strings.HasPrefix(next, "=") || strings.HasPrefix(next, ".") ||
+					(header && strings.HasPrefix(next, "]"))
+				if !isKey {
+					for j := i + 1; j < end-1; j++ {
+						b[j] = ' '
+					}
+				}
+				i = end - 1
+			}
+		}
+		masked[l] = string(b)
+	}
+	return masked
+}
+
+// closingTOMLQuote returns the offset just past the quote closing the single-line TOML string starting at `start`, or
+// -1. Basic strings (`"`) support escapes, literal strings (`'`) don't.
+func closingTOMLQuote(line string, start int) int {
+	quote := line[start]
+	for i := start + 1; i < len(line); i++ {
+		switch {
+		case quote == '"' && line[i] == '\\':
+			i++
+		case line[i] == quote:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// validator collects the problems of a single configuration file.
+type validator struct {
+	file string
+	// the project root tracked files are resolved against, or empty to skip checking they exist
+	root     string
+	problems []Problem
+}
+
+func (v *validator) add(node *yaml.Node, format string, args ...interface{}) {
+	p := Problem{File: v.file, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		p.Line, p.Column = node.Line, node.Column
+	}
+	v.problems = append(v.problems, p)
+}
+
+// validateConfig checks the structure of a configuration against Config, followed by the individual settings.
+func (v *validator) validateConfig(node *yaml.Node) {
+	v.validateStruct(node, reflect.TypeOf(Config{}), "")
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	if version := mappingValue(node, "version"); isString(version) && version.Value != "" {
+		// the version scheme may be set in an extended file
+		scheme := mappingValue(node, "version-scheme")
+		var err error
+		if isString(scheme) && isSettingValue("version-scheme", scheme.Value) {
+			_, err = semver.ParseWithScheme(version.Value, semver.VersionScheme(scheme.Value))
+		} else if _, err = semver.ParseSemVersion(version.Value); err != nil {
+			_, err = semver.ParseFourPartVersion(version.Value)
+		}
+		if err != nil {
+			v.add(version, "invalid version: %s", version.Value)
+		}
+	}
+	if labels := mappingValue(node, "prerelease-labels"); labels != nil && labels.Kind == yaml.SequenceNode {
+		seen := make(map[string]bool)
+		for _, label := range labels.Content {
+			if isString(label) && (label.Value == "" || !semver.ValidatePreReleaseLabels([]string{label.Value})) {
+				v.add(label, "invalid pre-release label '%s', labels must be alphabetic", label.Value)
+			} else if seen[label.Value] {
+				v.add(label, "duplicate pre-release label '%s'", label.Value)
+			}
+			seen[label.Value] = true
+		}
+	}
+	if channels := mappingValue(node, "prerelease-channels"); channels != nil && channels.Kind == yaml.SequenceNode {
+		seen := make(map[string]bool)
+		for i, channel := range channels.Content {
+			v.validateChannel(channel, fmt.Sprintf("prerelease-channels[%d]", i), seen)
+		}
+	}
+	if strategies := mappingValue(node, "strategies"); strategies != nil && strategies.Kind == yaml.SequenceNode {
+		defined := make(map[string]bool)
+		for i, strategy := range strategies.Content {
+			v.validateStrategy(strategy, fmt.Sprintf("strategies[%d]", i), defined)
+		}
+	}
+	if providers := mappingValue(node, "build-metadata"); providers != nil && providers.Kind == yaml.SequenceNode {
+		for _, provider := range providers.Content {
+			if isString(provider) && !IsBuildMetadataProvider(provider.Value) {
+				v.add(provider, "invalid build metadata provider, expected one of %s or env:NAME: %s",
+					strings.Join(BuildMetadataProviders, ", "), provider.Value)
+			}
+		}
+	}
+	for key, values := range settingValues {
+		if value := mappingValue(node, key); isString(value) && !isSettingValue(key, value.Value) {
+			v.add(value, "invalid %s, expected one of %s: %s", key, strings.Join(values, ", "), value.Value)
+		}
+	}
+	if label := mappingValue(node, "build-label"); isString(label) && !semver.ValidateBuildLabel(label.Value) {
+		v.add(label, "invalid build label '%s', the label must be alphanumeric", label.Value)
+	}
+	for _, key := range []string{"git-commit-template", "git-tag-template", "git-tag-message-template",
+		"describe-template"} {
+		if tmpl := mappingValue(node, key); isString(tmpl) && tmpl.Value != "" {
+			if _, err := ParseGitTemplate(key, tmpl.Value); err != nil {
+				v.add(tmpl, "%v", err)
+			}
+		}
+	}
+	if tmpl := mappingValue(node, "git-tag-template"); isString(tmpl) && tmpl.Value != "" {
+		if _, _, err := SplitTagTemplate(tmpl.Value); err != nil {
+			v.add(tmpl, "git-tag-template %v: %s", err, tmpl.Value)
+		}
+	}
+	if files := mappingValue(node, "files"); files != nil && files.Kind == yaml.SequenceNode {
+		for i, file := range files.Content {
+			v.validateFile(file, fmt.Sprintf("files[%d]", i))
+		}
+	}
+
+	// report the problems in the order they appear in the file
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].Line < v.problems[j].Line
+	})
+}
+
+// validateFile checks a `files` entry.
+func (v *validator) validateFile(node *yaml.Node, name string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	filePath := mappingValue(node, "path")
+	if !isString(filePath) || filePath.Value == "" {
+		v.add(node, "%s requires a path", name)
+	} else if v.root != "" {
+		// paths may reference environment variables, which are reported when loading the config if invalid
+		if p, err := InterpolateEnv(filePath.Value); err == nil && !utils.FileExists(ResolvePath(v.root, p)) {
+			v.add(filePath, "tracked file not found: %s", p)
+		}
+	}
+
+	replace := mappingValue(node, "replace")
+	if key := mappingValue(node, "key"); key != nil && replace != nil {
+		v.add(key, "%s: key and replace are mutually exclusive", name)
+	}
+	if replace != nil && replace.Kind == yaml.SequenceNode {
+		for _, pattern := range replace.Content {
+			if isString(pattern) && !strings.Contains(pattern.Value, "{version}") {
+				v.add(pattern, "replace pattern must contain {version}: %s", pattern.Value)
+			}
+		}
+	}
+	if format := mappingValue(node, "format"); isString(format) && !isSettingValue("files.format", format.Value) {
+		v.add(format, "invalid %s format, expected one of %s: %s", name,
+			strings.Join(settingValues["files.format"], ", "), format.Value)
+	}
+}
+
+// validateChannel checks a `prerelease-channels` entry. `seen` holds the names of the preceding channels.
+func (v *validator) validateChannel(node *yaml.Node, name string, seen map[string]bool) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	channelName := mappingValue(node, "name")
+	if !isString(channelName) || channelName.Value == "" {
+		v.add(node, "%s requires a name", name)
+		return
+	}
+	if seen[channelName.Value] {
+		v.add(channelName, "duplicate pre-release channel '%s'", channelName.Value)
+	}
+	seen[channelName.Value] = true
+
+	label := channelName
+	if l := mappingValue(node, "label"); isString(l) {
+		label = l
+	}
+	if label.Value == "" || !semver.ValidatePreReleaseLabels([]string{label.Value}) {
+		v.add(label, "invalid label '%s' for channel '%s', labels must be alphabetic", label.Value, channelName.Value)
+	}
+}
+
+// validateStrategy checks a `strategies` entry. `defined` holds the names of the preceding strategies, which can be
+// used as steps.
+func (v *validator) validateStrategy(node *yaml.Node, name string, defined map[string]bool) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	strategyName := mappingValue(node, "name")
+	if !isString(strategyName) || strategyName.Value == "" {
+		v.add(node, "%s requires a name", name)
+		return
+	}
+	if !semver.ValidateStrategyName(strategyName.Value) {
+		v.add(strategyName, "invalid strategy name '%s', names must be lowercase alphanumerics or '-'", strategyName.Value)
+	} else if _, ok := semver.LookupStrategy(strategyName.Value); ok {
+		v.add(strategyName, "strategy '%s' is already defined", strategyName.Value)
+	} else if defined[strategyName.Value] {
+		v.add(strategyName, "duplicate strategy '%s'", strategyName.Value)
+	}
+
+	steps := mappingValue(node, "steps")
+	if steps == nil || steps.Kind != yaml.SequenceNode || len(steps.Content) == 0 {
+		v.add(node, "strategy '%s' requires at least one step", strategyName.Value)
+	} else {
+		for _, step := range steps.Content {
+			if _, ok := semver.LookupStrategy(step.Value); isString(step) && !ok && !defined[step.Value] {
+				v.add(step, "unknown step '%s' of strategy '%s'", step.Value, strategyName.Value)
+			}
+		}
+	}
+	defined[strategyName.Value] = true
+}
+
+// validateStruct checks that a mapping node only has the keys of the given struct type, with values of the right
+// type.
+func (v *validator) validateStruct(node *yaml.Node, t reflect.Type, prefix string) {
+	if node.Kind != yaml.MappingNode {
+		if prefix == "" {
+			v.add(node, "the configuration must be a mapping of settings")
+		} else {
+			v.add(node, "%s must be a mapping", strings.TrimSuffix(prefix, "."))
+		}
+		return
+	}
+
+	fields := make(map[string]reflect.StructField)
+	keys := make([]string, 0)
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		fields[key] = t.Field(i)
+		keys = append(keys, key)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if prefix == "" && key.Value == "$schema" {
+			// editors locate the JSON Schema of a JSON config file with `$schema`
+			continue
+		}
+		field, ok := fields[key.Value]
+		if !ok {
+			if suggestion := closestKey(key.Value, keys); suggestion != "" {
+				v.add(key, "unknown setting '%s%s', did you mean '%s%s'?", prefix, key.Value, prefix, suggestion)
+			} else {
+				v.add(key, "unknown setting '%s%s'", prefix, key.Value)
+			}
+			continue
+		}
+		v.validateValue(value, field.Type, prefix+key.Value)
+	}
+}
+
+// validateValue checks that a node holds a value of the given type.
+func (v *validator) validateValue(node *yaml.Node, t reflect.Type, name string) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		// an empty value leaves the setting unset
+		return
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		v.validateStruct(node, t, name+".")
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			v.add(node, "%s must be a string", name)
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.add(node, "%s must be true or false", name)
+		}
+	case reflect.Slice:
+		if name == "extends" && node.Kind == yaml.ScalarNode {
+			// a single path is allowed
+			return
+		}
+		if node.Kind != yaml.SequenceNode {
+			v.add(node, "%s must be a list", name)
+			return
+		}
+		for i, item := range node.Content {
+			v.validateValue(item, t.Elem(), fmt.Sprintf("%s[%d]", name, i))
+		}
+	}
+}
+
+// isString returns true if the node is a scalar that can be used as a string.
+func isString(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && node.Tag != "!!null"
+}
+
+// closestKey returns the key closest to an unknown key, if it is likely a typo of it.
+func closestKey(unknown string, keys []string) string {
+	best, bestDistance := "", 3
+	for _, key := range keys {
+		if d := editDistance(unknown, key); d < bestDistance {
+			best, bestDistance = key, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
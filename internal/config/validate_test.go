@@ -0,0 +1,158 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestValidate tests that every problem is reported with its position
+func TestValidate(t *testing.T) {
+	dir, err := os.MkdirTemp("", "validateTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("v1.0.0\n"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected []Problem
+	}{
+		{
+			"versionbump.yaml",
+			`version: "1.0"
+git-tags: true
+git-sign: "yes"
+prerelease-labels: ["alpha", "rc1"]
+prerelease-channels:
+  - name: nightly
+  - name: beta
+    label: beta2
+  - name: nightly
+files:
+  - path: "README.md"
+    replace: ["v{version}"]
+  - path: "missing.md"
+    replace: ["no placeholder"]
+    format: cargo
+build-metadata: [sha, revision]
+strategies:
+  - name: finalize
+    steps: [release, patch]
+  - name: Ship
+    steps: [finalize, deploy]
+  - name: major
+    steps: []
+`,
+			[]Problem{
+				{Line: 1, Column: 10, Message: "invalid version: 1.0"},
+				{Line: 2, Column: 1, Message: "unknown setting 'git-tags', did you mean 'git-tag'?"},
+				{Line: 3, Column: 11, Message: "git-sign must be true or false"},
+				{Line: 4, Column: 30, Message: "invalid pre-release label 'rc1', labels must be alphabetic"},
+				{Line: 8, Column: 12, Message: "invalid label 'beta2' for channel 'beta', labels must be alphabetic"},
+				{Line: 9, Column: 11, Message: "duplicate pre-release channel 'nightly'"},
+				{Line: 13, Column: 11, Message: "tracked file not found: missing.md"},
+				{Line: 14, Column: 15, Message: "replace pattern must contain {version}: no placeholder"},
+				{Line: 15, Column: 13, Message: "invalid files[1] format, expected one of semver, pep440, npm, maven, nuget: cargo"},
+				{Line: 16, Column: 23, Message: "invalid build metadata provider, expected one of sha, timestamp, date, commits, dirty, ci or env:NAME: revision"},
+				{Line: 20, Column: 11, Message: "invalid strategy name 'Ship', names must be lowercase alphanumerics or '-'"},
+				{Line: 21, Column: 23, Message: "unknown step 'deploy' of strategy 'Ship'"},
+				{Line: 22, Column: 11, Message: "strategy 'major' is already defined"},
+				{Line: 22, Column: 5, Message: "strategy 'major' requires at least one step"},
+			},
+		},
+		{
+			"versionbump.json",
+			`{
+  "version": "1.0.0",
+  "git-commit-template": "bump {{.New",
+  "git-tag-template": "v{{.New | upper}}",
+  "files": [
+    {"path": "README.md", "replace": ["v{version}"], "replaces": []}
+  ]
+}
+`,
+			[]Problem{
+				{Line: 3, Column: 26, Message: "error parsing git-commit-template template: template: git-commit-template:1: unclosed action"},
+				{Line: 4, Column: 23, Message: "git-tag-template must reference the new version with {new} or a bare {{.New}}, other template actions can't be used to extract versions from tags: v{{.New | upper}}"},
+				{Line: 6, Column: 54, Message: "unknown setting 'files[0].replaces', did you mean 'files[0].replace'?"},
+			},
+		},
+		{
+			"versionbump.toml",
+			`version = "1.0.0"
+git-tag-template = "release"
+
+[[files]]
+path = "README.md"
+replace = ["v{version}"]
+
+[[files]]
+path = "missing.md"
+replace = ["v{version}"]
+`,
+			[]Problem{
+				{Line: 2, Column: 1, Message: "git-tag-template must contain the new version ({new} or {{.New}}): release"},
+				{Line: 9, Column: 1, Message: "tracked file not found: missing.md"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filePath := filepath.Join(dir, test.name)
+			if err := os.WriteFile(filePath, []byte(test.content), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+			defer os.Remove(filePath)
+
+			problems := Validate(filePath)
+			if len(problems) != len(test.expected) {
+				t.Fatalf("Expected %d problems, but got %d: %v", len(test.expected), len(problems), problems)
+			}
+			for i, expected := range test.expected {
+				expected.File = filePath
+				if problems[i] != expected {
+					t.Errorf("Expected problem '%s', but got '%s'", expected, problems[i])
+				}
+			}
+		})
+	}
+}
+
+// TestLoadConfigUnknownSetting tests that LoadConfig rejects unknown settings instead of ignoring them
+func TestLoadConfigUnknownSetting(t *testing.T) {
+	dir, err := os.MkdirTemp("", "loadConfigUnknownSettingTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	if err := os.WriteFile(filePath, []byte("version: \"1.0.0\"\nprerelase-labels: [\"dev\"]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if _, _, err := LoadConfig(filePath); err == nil {
+		t.Errorf("Expected an error for an unknown setting, but got none")
+	}
+}
+
+// TestSchema tests that the published JSON Schema is up to date with the Config struct
+func TestSchema(t *testing.T) {
+	expected, err := FormatJSON.Marshal(Schema())
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+	actual, err := os.ReadFile("../../versionbump.schema.json")
+	if err != nil {
+		t.Fatalf("Failed to read schema file: %v", err)
+	}
+	if string(actual) != string(expected) {
+		t.Errorf("versionbump.schema.json is out of date, regenerate it with `versionbump schema`")
+	}
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/ptgoetz/go-versionbump/internal/git"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// DescribeData is the data model available to the describe template.
+type DescribeData struct {
+	Tag     string                  // The latest version tag reachable from the commit (empty if there is none)
+	Version *semver.SemanticVersion // The version of the tag, or the initial version if there is no tag
+	Next    *semver.SemanticVersion // The next patch version of a release, or the pre-release version itself
+	Commits int                     // The number of commits since the tag
+	SHA     string                  // The abbreviated SHA of the commit
+	Dirty   bool                    // Whether the working tree has uncommitted changes (only when describing HEAD)
+}
+
+// Describe computes a version for a commit (HEAD if empty) from the latest version tag reachable from it, in the
+// style of `git describe`, e.g. `1.4.1-dev.7+g1a2b3c4`. A commit that is tagged with a version is described by that
+// version, unless the working tree has uncommitted changes. No files are modified.
+func (vb *VersionBump) Describe(commit string) (string, error) {
+	if vb.Config.IsFourPart() {
+		return "", fmt.Errorf("describe requires semantic versions, but the version-scheme is %s", vb.Config.VersionScheme)
+	}
+	data, err := vb.describeData(commit)
+	if err != nil {
+		return "", err
+	}
+	if data.Commits == 0 && !data.Dirty && data.Tag != "" {
+		return data.Version.String(), nil
+	}
+	template := vb.Config.DescribeTemplate
+	if vb.Options.DescribeTemplate != "" {
+		template = vb.Options.DescribeTemplate
+	}
+	description, err := RenderTemplate("describe", template, data)
+	if err != nil {
+		return "", err
+	}
+	if !semver.IsSpecCompliant(description) {
+		return "", fmt.Errorf("the describe template produced an invalid version: %s", description)
+	}
+	return description, nil
+}
+
+// describeData collects the git state used to describe a commit.
+func (vb *VersionBump) describeData(commit string) (*DescribeData, error) {
+	rev := commit
+	if rev == "" {
+		rev = "HEAD"
+	}
+	if strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid commit: %s", rev)
+	}
+	if isRepo, _ := git.IsRepository(vb.ParentDir); !isRepo {
+		return nil, fmt.Errorf("the project root is not a git repository: %s", vb.ParentDir)
+	}
+
+	sha, err := git.GetCommitSHA(vb.ParentDir, rev)
+	if err != nil {
+		return nil, err
+	}
+	tag, version, err := vb.versionTag(rev)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := git.CountCommits(vb.ParentDir, tag, rev)
+	if err != nil {
+		return nil, err
+	}
+	data := &DescribeData{Tag: tag, Version: version, Next: version, Commits: commits, SHA: sha}
+	if version == nil {
+		data.Version, _ = semver.ParseSemVersion(config.DefaultVersion)
+		data.Next = data.Version
+	}
+	// a pre-release already precedes its release, so only a release is followed by the next patch version
+	if !data.Version.IsPreRelease() {
+		data.Next, err = data.Version.Bump(semver.Patch, vb.Config.GetSortedLabels(), vb.Config.BuildLabel)
+		if err != nil {
+			return nil, err
+		}
+	}
+	data.Next = data.Next.WithoutBuild()
+	if commit == "" {
+		data.Dirty, err = git.HasPendingChanges(vb.ParentDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// versionTag returns the latest version tag reachable from the commit, matched through `git-tag-template`, and its
+// version. The tag is empty if no version has been tagged yet.
+func (vb *VersionBump) versionTag(rev string) (string, *semver.SemanticVersion, error) {
+	tags, err := git.GetMergedTags(vb.ParentDir, rev)
+	if err != nil {
+		return "", nil, err
+	}
+	var latest *semver.SemanticVersion
+	latestTag := ""
+	for _, tag := range tags {
+		vStr, err := ExtractVersion(vb.Config.GitTagTemplate, tag)
+		if err != nil {
+			continue
+		}
+		v, err := vb.Config.ParseVersion(vStr)
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.CompareWithLabels(latest, vb.Config.GetSortedLabels()) > 0 {
+			latest, latestTag = v, tag
+		}
+	}
+	return latestTag, latest, nil
+}
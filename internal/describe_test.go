@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	dir, err := os.MkdirTemp("", "describeTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	content := "version-source:\n  latest-tag: true\ngit-tag-template: \"v{new}\"\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+
+	newGitRepo(t, dir)
+
+	describe := func(commit string) string {
+		vb, err := NewVersionBump(config.Options{ConfigPath: filePath, Quiet: true})
+		assert.NoError(t, err)
+		version, err := vb.Describe(commit)
+		assert.NoError(t, err)
+		return version
+	}
+
+	// without a tag, the initial version is described
+	sha := runGit(t, dir, "rev-parse", "--short=7", "HEAD")
+	assert.Equal(t, "0.0.1-dev.1+g"+sha, describe(""))
+
+	// a tagged commit is described by its version
+	runGit(t, dir, "tag", "v1.4.0")
+	assert.Equal(t, "1.4.0", describe(""))
+
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Second commit")
+	runGit(t, dir, "commit", "--allow-empty", "-m", "Third commit")
+	sha = runGit(t, dir, "rev-parse", "--short=7", "HEAD")
+	assert.Equal(t, "1.4.1-dev.2+g"+sha, describe(""))
+	assert.Equal(t, "1.4.1-dev.1+g"+runGit(t, dir, "rev-parse", "--short=7", "HEAD~1"), describe("HEAD~1"))
+
+	// a pre-release is extended, so the version precedes the next pre-release
+	runGit(t, dir, "tag", "v1.5.0-rc.2", "HEAD~1")
+	assert.Equal(t, "1.5.0-rc.2.dev.1+g"+sha, describe(""))
+
+	// uncommitted changes are only marked when describing the working tree
+	if err := os.WriteFile(filePath, []byte(content+"# changed\n"), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+	assert.Equal(t, "1.5.0-rc.2.dev.1+g"+sha+"-dirty", describe(""))
+	assert.Equal(t, "1.5.0-rc.2.dev.1+g"+sha, describe("HEAD"))
+
+	vb, err := NewVersionBump(config.Options{ConfigPath: filePath, Quiet: true, DescribeTemplate: "v{{.Next}}"})
+	assert.NoError(t, err)
+	_, err = vb.Describe("")
+	assert.Error(t, err, "expected an error for a template producing an invalid version")
+	_, err = vb.Describe("--all")
+	assert.Error(t, err, "expected an error for an option instead of a commit")
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// Diff classifies the change between two versions, ordering pre-release labels as configured.
+func (vb *VersionBump) Diff(fromStr string, toStr string) (semver.Difference, error) {
+	from, err := vb.Config.ParseVersion(fromStr)
+	if err != nil {
+		return semver.Difference{}, err
+	}
+	to, err := vb.Config.ParseVersion(toStr)
+	if err != nil {
+		return semver.Difference{}, err
+	}
+	return semver.DiffWithLabels(from, to, vb.Config.GetSortedLabels()), nil
+}
+
+// DiffVersions prints the change between two versions (see ShowDiff). The versions are given, so no project is
+// needed: the configuration file only orders the pre-release labels and sets the version scheme, and git isn't run.
+func DiffVersions(opts config.Options, fromStr string, toStr string) error {
+	cfg, err := loadOptionalConfig(opts, true)
+	if err != nil {
+		return err
+	}
+	vb := &VersionBump{Config: *cfg, Options: opts}
+	return vb.ShowDiff(fromStr, toStr)
+}
+
+// ShowDiff prints the change between two versions, and warns about unusual transitions.
+func (vb *VersionBump) ShowDiff(fromStr string, toStr string) error {
+	d, err := vb.Diff(fromStr, toStr)
+	if err != nil {
+		return err
+	}
+	stability := "unchanged"
+	if changes := d.StabilityChanges(); len(changes) > 0 {
+		stability = strings.Join(changes, ", ")
+	}
+	fmt.Printf("From:      %s\n", d.From)
+	fmt.Printf("To:        %s\n", d.To)
+	fmt.Printf("Change:    %s\n", d.Component)
+	fmt.Printf("Direction: %s\n", d.Direction)
+	fmt.Printf("Stability: %s\n", stability)
+	for _, warning := range transitionWarnings(d) {
+		logWarning(vb.Options, warning)
+	}
+	return nil
+}
+
+// transitionWarnings returns warnings about unusual transitions: versions that move backwards, don't change or skip
+// versions. Build metadata has no precedence, so changes of the build metadata only are not reported.
+func transitionWarnings(d semver.Difference) []string {
+	var warnings []string
+	switch {
+	case d.Component == semver.ComponentNone:
+		warnings = append(warnings, fmt.Sprintf("the version %s doesn't change", d.From))
+	case d.Component == semver.ComponentBuild:
+	case d.Direction == semver.DirectionDown:
+		warnings = append(warnings, fmt.Sprintf("the version moves backwards: %s -> %s (%s)", d.From, d.To, d.Component))
+	case d.SkipsVersions():
+		warnings = append(warnings, fmt.Sprintf("the version skips versions: %s -> %s (%s)", d.From, d.To, d.Component))
+	}
+	return warnings
+}
+
+// bumpTransitionWarnings returns the transitionWarnings of the version bump. Pre-release channels are numbered
+// independently, so switching channels may move the version backwards.
+func (vb *VersionBump) bumpTransitionWarnings() []string {
+	d, err := vb.Diff(vb.GetOldVersion(), vb.GetNewVersion())
+	if err != nil {
+		return nil
+	}
+	if vb.Options.Channel != "" && d.Direction == semver.DirectionDown {
+		return nil
+	}
+	return transitionWarnings(d)
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionWarnings(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		warnings int
+	}{
+		{"1.2.3", "1.2.4", 0},
+		{"1.2.3-rc.1", "1.2.3", 0},
+		{"1.2.3", "1.2.2", 1},
+		{"1.2.3", "1.2.3", 1},
+		{"1.2.3", "1.4.0", 1},
+		{"1.2.3+sha.f00", "1.2.3+sha.abc", 0},
+	}
+	for _, test := range tests {
+		d := semver.Diff(semver.MustParse(test.from), semver.MustParse(test.to))
+		assert.Len(t, transitionWarnings(d), test.warnings, "warnings for %s -> %s", test.from, test.to)
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+	dir, err := os.MkdirTemp("", "diffVersionsTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// a tag-driven project outside a git repository, git isn't needed to compare versions
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	content := "version-source:\n  latest-tag: true\nprerelease-labels: [\"rc\", \"beta\"]\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+	assert.NoError(t, DiffVersions(config.Options{ConfigPath: filePath, Quiet: true}, "1.0.0-rc", "1.0.0-beta"))
+	assert.Error(t, DiffVersions(config.Options{ConfigPath: filePath}, "1.0.0", "not a version"))
+	assert.Error(t, DiffVersions(config.Options{ConfigPath: filepath.Join(dir, "missing.yaml")}, "1.0.0", "2.0.0"))
+}
@@ -6,6 +6,7 @@ import (
 	vbc "github.com/ptgoetz/go-versionbump/internal/config"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -117,7 +118,12 @@ func GetTags(projectDir string) ([]string, error) {
 		return nil, fmt.Errorf("failed to get git tags: %w", err)
 	}
 	// Convert the output to a slice of strings, one per line
-	tags := strings.Split(strings.TrimSpace(out), "\n")
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(strings.TrimSpace(out), "\n") {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
 	return tags, nil
 }
 
@@ -144,6 +150,56 @@ func GetCurrentBranch(projectDir string) (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
+// GetUserName returns the git user name configured for the given project directory
+func GetUserName(projectDir string) (string, error) {
+	out, _, err := runGitCommand(projectDir, "config", "--get", "user.name")
+	if err != nil {
+		return "", fmt.Errorf("failed to get git user name: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// GetCommitSHA returns the abbreviated SHA of a commit (e.g. HEAD) in the given project directory
+func GetCommitSHA(projectDir string, rev string) (string, error) {
+	out, _, err := runGitCommand(projectDir, "rev-parse", "--verify", "--short=7", rev+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit SHA of %s: %w", rev, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// CountCommits returns the number of commits on `rev` since the given revision (e.g. a tag), or all commits on `rev`
+// if `since` is empty
+func CountCommits(projectDir string, since string, rev string) (int, error) {
+	if since != "" {
+		rev = since + ".." + rev
+	}
+	out, _, err := runGitCommand(projectDir, "rev-list", "--count", rev)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits: %w", err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(out))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits: %w", err)
+	}
+	return count, nil
+}
+
+// GetMergedTags returns the git tags reachable from the given commit
+func GetMergedTags(projectDir string, rev string) ([]string, error) {
+	out, _, err := runGitCommand(projectDir, "tag", "--list", "--merged", rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git tags: %w", err)
+	}
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(strings.TrimSpace(out), "\n") {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
 // runGitCommand runs a git command in the specified directory and returns the output and error messages.
 func runGitCommand(root string, args ...string) (string, string, error) {
 	absPath, err := filepath.Abs(root)
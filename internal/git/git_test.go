@@ -104,3 +104,68 @@ func TestInitializeGitRepo(t *testing.T) {
 		t.Fatalf("Expected .git directory to exist, but it does not")
 	}
 }
+
+// TestCommitSHAAndCount tests the GetCommitSHA, CountCommits and GetMergedTags functions
+func TestCommitSHAAndCount(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gitrepo")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := InitializeGitRepo(dir); err != nil {
+		t.Fatalf("Failed to initialize git repository: %v", err)
+	}
+	commit := func(message string) {
+		_, _, err := runGitCommand(dir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+			"-c", "commit.gpgsign=false", "commit", "--allow-empty", "-q", "-m", message)
+		if err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+	}
+	commit("first")
+	if _, _, err := runGitCommand(dir, "tag", "v1.0.0"); err != nil {
+		t.Fatalf("Failed to tag: %v", err)
+	}
+	commit("second")
+	commit("third")
+
+	sha, err := GetCommitSHA(dir, "HEAD")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sha) < 7 {
+		t.Errorf("Expected an abbreviated commit SHA, but got '%s'", sha)
+	}
+
+	tests := []struct {
+		since    string
+		expected int
+	}{
+		{"", 3},
+		{"v1.0.0", 2},
+	}
+	for _, test := range tests {
+		count, err := CountCommits(dir, test.since, "HEAD")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if count != test.expected {
+			t.Errorf("Expected %d commits since '%s', but got %d", test.expected, test.since, count)
+		}
+	}
+
+	if _, _, err := runGitCommand(dir, "tag", "v1.1.0", "HEAD~1"); err != nil {
+		t.Fatalf("Failed to tag: %v", err)
+	}
+	tags, err := GetMergedTags(dir, "HEAD~2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tags) != 1 || tags[0] != "v1.0.0" {
+		t.Errorf("Expected only tag v1.0.0 to be reachable, but got %v", tags)
+	}
+	if _, err := GetCommitSHA(dir, "no-such-commit"); err == nil {
+		t.Errorf("Expected an error for an unknown commit")
+	}
+}
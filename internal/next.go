@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// NextVersion returns the version a strategy, or a chain of strategies (e.g. `minor,pre`), bumps the project version
+// to, or the version given with `--from`. Nothing is changed, and git isn't run, so the version of a project that
+// derives it from git tags must be given with `--from`. Without a configuration file, the defaults are used. Build
+// metadata providers aren't applied, as they depend on git and the environment.
+func NextVersion(opts config.Options, strategyChain string) (string, error) {
+	opts.NoGit = true
+	cfg, err := loadOptionalConfig(opts, opts.NextOpts.From != "")
+	if err != nil {
+		return "", err
+	}
+
+	versionStr := opts.NextOpts.From
+	if versionStr == "" {
+		if cfg.IsLatestTagSource() {
+			return "", fmt.Errorf("the project version is derived from git tags, use --from to give the version")
+		}
+		versionStr = cfg.Version
+	}
+	current, err := cfg.ParseVersion(versionStr)
+	if err != nil {
+		return "", err
+	}
+	strategy, err := cfg.GetStrategyChain(strategyChain)
+	if err != nil {
+		return "", err
+	}
+	next, err := strategy.Apply(current, semver.StrategyConfig{
+		PreReleaseLabels: cfg.GetSortedLabels(),
+		BuildLabel:       cfg.BuildLabel,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if opts.NextOpts.Tag {
+		files := make([]string, 0, len(cfg.Files))
+		for _, file := range cfg.Files {
+			files = append(files, file.Path)
+		}
+		return RenderTemplate("git-tag", cfg.GitTagTemplate, TemplateData{
+			Old:      current,
+			New:      next,
+			Date:     time.Now(),
+			Strategy: strategy.Name(),
+			Files:    files,
+		})
+	}
+	if opts.NextOpts.Format != "" {
+		if !semver.IsDialect(opts.NextOpts.Format) {
+			return "", fmt.Errorf("invalid format, expected one of %s: %s", dialectNames(), opts.NextOpts.Format)
+		}
+		return next.ToDialect(semver.Dialect(opts.NextOpts.Format))
+	}
+	return next.String(), nil
+}
+
+// loadOptionalConfig loads the configuration file given with `--config`, or found from the current directory. Commands
+// that don't need a project, e.g. `next --from`, pass `optional` to use the defaults if no configuration file is
+// found. A configuration file given with `--config` must exist.
+func loadOptionalConfig(opts config.Options, optional bool) (*config.Config, error) {
+	configPath := opts.ConfigPath
+	if configPath == "" {
+		var err error
+		if configPath, err = config.FindConfig("."); err != nil {
+			if optional {
+				return config.NewConfig(), nil
+			}
+			return nil, err
+		}
+	}
+	cfg, _, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading configuration file: %v", err)
+	}
+	return cfg, nil
+}
+
+// dialectNames returns the names of the version dialects, e.g. for error messages
+func dialectNames() string {
+	names := make([]string, len(semver.Dialects))
+	for i, d := range semver.Dialects {
+		names[i] = string(d)
+	}
+	return strings.Join(names, ", ")
+}
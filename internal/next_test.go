@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextVersion(t *testing.T) {
+	dir, err := os.MkdirTemp("", "nextVersionTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	content := `version: "1.2.3-rc.1"
+git-tag-template: "release-{new}"
+strategies:
+  - name: finalize
+    steps: [release, patch]
+`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+
+	tests := []struct {
+		strategy string
+		next     config.NextOptions
+		expected string
+	}{
+		{"minor", config.NextOptions{}, "1.3.0"},
+		{"finalize", config.NextOptions{}, "1.2.4"},
+		{"minor,pre", config.NextOptions{}, "1.3.0-alpha"},
+		{"pre-major", config.NextOptions{Format: "pep440"}, "1.2.3rc2"},
+		{"minor", config.NextOptions{Tag: true}, "release-1.3.0"},
+		{"major", config.NextOptions{From: "2.0.0"}, "3.0.0"},
+	}
+	for _, test := range tests {
+		version, err := NextVersion(config.Options{ConfigPath: filePath, NextOpts: test.next}, test.strategy)
+		assert.NoError(t, err, test.strategy)
+		assert.Equal(t, test.expected, version, "next %s %+v", test.strategy, test.next)
+	}
+
+	_, err = NextVersion(config.Options{ConfigPath: filePath}, "deploy")
+	assert.Error(t, err)
+	_, err = NextVersion(config.Options{ConfigPath: filePath, NextOpts: config.NextOptions{Format: "cargo"}}, "minor")
+	assert.Error(t, err)
+
+	// the configuration file isn't changed
+	actual, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(actual))
+}
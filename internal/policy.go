@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// checkVersionPolicy checks a new version against the existing (tagged) versions. Versions that already exist, or are
+// lower than the latest existing version on the same release line (the same major and minor version), are returned
+// as errors. A new version that skips versions after the highest lower existing version is returned as a warning,
+// unless that's the current version, whose transition is reported by bumpPreflight. Build metadata has no precedence,
+// so it's ignored when comparing versions: 1.2.0+sha.abc already exists if 1.2.0 is tagged.
+func checkVersionPolicy(current *semver.SemanticVersion, newVersion *semver.SemanticVersion,
+	existing []*semver.SemanticVersion, labels []string) (errs []string, warnings []string) {
+
+	newPrecedence := newVersion.WithoutBuild()
+	var latestOnLine, predecessor *semver.SemanticVersion
+	for _, v := range existing {
+		cmp := v.WithoutBuild().CompareWithLabels(newPrecedence, labels)
+		if cmp == 0 {
+			if v.String() == newVersion.String() {
+				errs = append(errs, fmt.Sprintf("version %s already exists", newVersion))
+			} else {
+				errs = append(errs, fmt.Sprintf("version %s already exists as %s", newVersion, v))
+			}
+			return errs, nil
+		}
+		onLine := v.RootVersion().Major() == newVersion.RootVersion().Major() &&
+			v.RootVersion().Minor() == newVersion.RootVersion().Minor()
+		if onLine && cmp > 0 && (latestOnLine == nil || v.CompareWithLabels(latestOnLine, labels) > 0) {
+			latestOnLine = v
+		}
+		if cmp < 0 && (predecessor == nil || v.CompareWithLabels(predecessor, labels) > 0) {
+			predecessor = v
+		}
+	}
+
+	if latestOnLine != nil {
+		errs = append(errs, fmt.Sprintf("version %s is lower than the latest version %s on the same release line",
+			newVersion, latestOnLine))
+	} else if predecessor != nil && predecessor.String() != current.String() &&
+		semver.DiffWithLabels(predecessor, newVersion, labels).SkipsVersions() {
+		warnings = append(warnings, fmt.Sprintf("version %s skips versions after the latest lower version %s",
+			newVersion, predecessor))
+	}
+	return errs, warnings
+}
+
+// versionPolicyPreflight checks the new version against the versions tagged in git, as configured by
+// `version-policy`. With the strict policy, problems are fatal unless forced. On a pre-release channel, only the
+// versions of the channel are considered, as channels are numbered independently.
+func (vb *VersionBump) versionPolicyPreflight() {
+	if vb.Config.VersionPolicy == config.VersionPolicyOff || vb.Options.NoGit {
+		return
+	}
+	versions, err := vb.GetSortedVersions()
+	if err != nil {
+		msg := fmt.Sprintf("unable to read the git tags for the version policy check: %v", err)
+		if vb.Config.VersionPolicy == config.VersionPolicyStrict && !vb.Options.Force {
+			logFatal(vb.Options, fmt.Sprintf("%s (use --force to override, or configure version-policy)", msg))
+		}
+		logWarning(vb.Options, msg)
+		return
+	}
+	current, err := vb.Config.ParseVersion(vb.GetOldVersion())
+	if err != nil {
+		return
+	}
+	newVersion, err := vb.Config.ParseVersion(vb.GetNewVersion())
+	if err != nil {
+		return
+	}
+	if vb.Options.Channel != "" {
+		channelVersions := make([]*semver.SemanticVersion, 0)
+		for _, v := range versions {
+			if v.PreReleaseVersion().Label() == newVersion.PreReleaseVersion().Label() {
+				channelVersions = append(channelVersions, v)
+			}
+		}
+		versions = channelVersions
+	}
+
+	errs, warnings := checkVersionPolicy(current, newVersion, versions, vb.Config.GetSortedLabels())
+	for _, msg := range errs {
+		if vb.Config.VersionPolicy == config.VersionPolicyStrict && !vb.Options.Force {
+			logFatal(vb.Options, fmt.Sprintf("%s (use --force to override, or configure version-policy)", msg))
+		}
+		logWarning(vb.Options, msg)
+	}
+	for _, msg := range warnings {
+		logWarning(vb.Options, msg)
+	}
+}
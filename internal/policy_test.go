@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckVersionPolicy(t *testing.T) {
+	var existing []*semver.SemanticVersion
+	for _, v := range []string{"1.2.0", "1.3.0-rc.1", "1.3.0", "2.0.0+build.1"} {
+		existing = append(existing, semver.MustParse(v))
+	}
+	labels := []string{"alpha", "beta", "rc"}
+
+	tests := []struct {
+		current  string
+		new      string
+		errs     int
+		warnings int
+	}{
+		{"1.3.0", "1.3.1", 0, 0},
+		{"1.3.0", "1.3.0", 1, 0},
+		{"1.3.0", "1.2.0", 1, 0},
+		{"1.3.0", "1.3.0-beta.1", 1, 0},
+		{"1.3.0", "1.2.1", 0, 0},         // a new patch of an earlier release line
+		{"2.0.0", "2.0.0+build.2", 1, 0}, // build metadata has no precedence
+		{"2.0.0", "2.0.0+build.1", 1, 0},
+		{"2.0.0", "2.0.0", 1, 0},
+		{"1.3.0", "1.3.0+sha.abc", 1, 0},
+		{"1.3.0", "1.5.0", 0, 0}, // skipping versions after the current version is reported by bumpPreflight
+		{"1.2.1", "1.2.3", 0, 1},
+		{"2.0.0", "4.0.0", 0, 1},
+	}
+	for _, test := range tests {
+		errs, warnings := checkVersionPolicy(semver.MustParse(test.current), semver.MustParse(test.new), existing, labels)
+		assert.Len(t, errs, test.errs, "errors for %s -> %s: %v", test.current, test.new, errs)
+		assert.Len(t, warnings, test.warnings, "warnings for %s -> %s: %v", test.current, test.new, warnings)
+	}
+}
@@ -0,0 +1,211 @@
+package internal
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+)
+
+// maxScanFileSize is the size of the largest file ScanVersionFiles searches for the version.
+const maxScanFileSize = 1024 * 1024
+
+// scanSkipDirs are directories that never contain tracked files.
+var scanSkipDirs = map[string]bool{
+	".git":         true,
+	".hg":          true,
+	".svn":         true,
+	".idea":        true,
+	".vscode":      true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// ScanVersionFiles searches the files below `root` for the given version string, and proposes a `files` entry for
+// each file that contains it. Each occurrence of the version gets the shortest replacement pattern that is unique in
+// the file. Binary files, large files and VersionBump configuration files are skipped.
+func ScanVersionFiles(root string, version string) ([]config.VersionedFile, error) {
+	files := make([]config.VersionedFile, 0)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && scanSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || isConfigFileName(d.Name()) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > maxScanFileSize {
+			return nil
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if isBinary(content) {
+			return nil
+		}
+		patterns := VersionPatterns(string(content), version)
+		if len(patterns) == 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, config.VersionedFile{Path: filepath.ToSlash(rel), Replace: patterns})
+		return nil
+	})
+	return files, err
+}
+
+// VersionPatterns returns a replacement pattern for each occurrence of the version in the content. A pattern is
+// grown from the version by adding the surrounding tokens on the same line, until it matches only once in the
+// content and names what the version is (i.e. includes a word).
+func VersionPatterns(content string, version string) []string {
+	patterns := make([]string, 0)
+	seen := make(map[string]bool)
+	for offset := 0; ; {
+		i := strings.Index(content[offset:], version)
+		if i < 0 {
+			break
+		}
+		start := offset + i
+		end := start + len(version)
+		offset = end
+		// skip matches that are part of a longer version, e.g. 1.2.3 in 11.2.3 or 1.2.30
+		if !isVersionBoundary(content, start, end) {
+			continue
+		}
+
+		pattern := versionPattern(content, start, end)
+		if !seen[pattern] {
+			seen[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// versionPattern returns the pattern for the occurrence of the version at content[start:end].
+func versionPattern(content string, start int, end int) string {
+	lineStart := strings.LastIndexByte(content[:start], '\n') + 1
+	lineEnd := len(content)
+	if i := strings.IndexByte(content[end:], '\n'); i >= 0 {
+		lineEnd = end + i
+	}
+
+	// grow to the left first, since keys and labels usually precede the value
+	left, right := start, end
+	for {
+		candidate := content[left:right]
+		hasWord := strings.IndexFunc(content[left:start]+content[end:right], isWordRune) >= 0
+		if strings.Count(content, candidate) == 1 && hasWord {
+			break
+		}
+		if left > lineStart {
+			left = tokenStart(content, lineStart, left)
+		} else if right < lineEnd {
+			right = tokenEnd(content, right, lineEnd)
+		} else {
+			// the whole line isn't unique, so the pattern replaces every copy of it
+			break
+		}
+	}
+
+	// don't leave a quoted key or value half-quoted
+	if left > lineStart && isQuote(content[left-1]) {
+		left--
+	}
+	if right < lineEnd && isQuote(content[right]) && strings.Count(content[left:right], content[right:right+1])%2 == 1 {
+		right++
+	}
+
+	return content[left:start] + "{version}" + content[end:right]
+}
+
+// tokenStart returns the start of the token preceding `pos`. Whitespace is included with the token before it, and a
+// token is either a run of word characters or a single other character.
+func tokenStart(content string, lineStart int, pos int) int {
+	for pos > lineStart && (content[pos-1] == ' ' || content[pos-1] == '\t') {
+		pos--
+	}
+	if pos > lineStart && !isWordByte(content[pos-1]) {
+		return pos - 1
+	}
+	for pos > lineStart && isWordByte(content[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// tokenEnd returns the end of the token following `pos`, see tokenStart.
+func tokenEnd(content string, pos int, lineEnd int) int {
+	for pos < lineEnd && (content[pos] == ' ' || content[pos] == '\t') {
+		pos++
+	}
+	if pos < lineEnd && !isWordByte(content[pos]) {
+		return pos + 1
+	}
+	for pos < lineEnd && isWordByte(content[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// isVersionBoundary returns true if the version at content[start:end] is not part of a longer version number.
+func isVersionBoundary(content string, start int, end int) bool {
+	if start > 0 && (isDigit(content[start-1]) || content[start-1] == '.') {
+		return false
+	}
+	if end < len(content) && isDigit(content[end]) {
+		return false
+	}
+	if end+1 < len(content) && content[end] == '.' && isDigit(content[end+1]) {
+		return false
+	}
+	return true
+}
+
+// isConfigFileName returns true if the file name is one of the VersionBump configuration file names.
+func isConfigFileName(name string) bool {
+	for _, configName := range config.ConfigFileNames {
+		if name == configName {
+			return true
+		}
+	}
+	return false
+}
+
+// isBinary returns true if the content looks like a binary file, i.e. has a NUL byte near the start.
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+func isWordRune(r rune) bool {
+	return r < 0x80 && isWordByte(byte(r)) && !isDigit(byte(r))
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || isDigit(b) || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isQuote(b byte) bool {
+	return b == '"' || b == '\''
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+)
+
+func TestVersionPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{"go constant", "package main\n\nconst Version = \"1.2.3\"\n", []string{"Version = \"{version}\""}},
+		{
+			"package.json",
+			"{\n  \"name\": \"app\",\n  \"version\": \"1.2.3\",\n  \"dependencies\": {\"lib\": \"1.2.3\"}\n}\n",
+			[]string{"\"version\": \"{version}\"", "\"lib\": \"{version}\""},
+		},
+		{"Chart.yaml", "version: 1.2.3\nappVersion: \"1.2.3\"\n", []string{"version: {version}", "appVersion: \"{version}\""}},
+		{"Dockerfile", "FROM alpine\nLABEL version=\"1.2.3\"\n", []string{"version=\"{version}\""}},
+		{"README badge", "![version](https://img.shields.io/badge/version-1.2.3-blue)\n", []string{"version-{version}"}},
+		{"longer versions", "11.2.3 1.2.30 1.2.3.4 v1.2.3\n", []string{"v{version}"}},
+		{"no match", "nothing to see here\n", []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := VersionPatterns(test.content, "1.2.3")
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("Expected %q, but got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestScanVersionFiles(t *testing.T) {
+	dir, err := os.MkdirTemp("", "scanVersionFilesTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"main.go":                   "package main\n\nconst Version = \"1.2.3\"\n",
+		"README.md":                 "# App v1.2.3\n",
+		"docs/other.md":             "Nothing here\n",
+		"node_modules/lib/index.js": "module.exports = '1.2.3'\n",
+		"versionbump.yaml":          "version: \"1.2.3\"\n",
+		"assets/logo.bin":           "\x001.2.3",
+		"charts/app/Chart.yaml":     "version: 1.2.3\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+
+	actual, err := ScanVersionFiles(dir, "1.2.3")
+	if err != nil {
+		t.Fatalf("ScanVersionFiles failed: %v", err)
+	}
+	expected := []config.VersionedFile{
+		{Path: "README.md", Replace: []string{"v{version}"}},
+		{Path: "charts/app/Chart.yaml", Replace: []string{"version: {version}"}},
+		{Path: "main.go", Replace: []string{"Version = \"{version}\""}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %+v, but got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,246 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ptgoetz/go-versionbump/internal/utils"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// Show output formats
+const (
+	// ShowFormatText is a Unicode tree
+	ShowFormatText = "text"
+	// ShowFormatJSON is the VersionNode tree as JSON
+	ShowFormatJSON = "json"
+	// ShowFormatMermaid is a Mermaid flowchart, e.g. for Markdown documents
+	ShowFormatMermaid = "mermaid"
+	// ShowFormatDOT is a Graphviz DOT graph
+	ShowFormatDOT = "dot"
+)
+
+// ShowFormats are the output formats of the show command.
+var ShowFormats = []string{ShowFormatText, ShowFormatJSON, ShowFormatMermaid, ShowFormatDOT}
+
+// MaxShowDepth is the maximum depth of the version tree. Each level multiplies the size of the tree by the number of
+// strategies, so deeper trees are too large to be useful.
+const MaxShowDepth = 3
+
+// VersionNode is a version in the tree of potential versioning paths. Its children are the versions each strategy
+// bumps it to.
+type VersionNode struct {
+	// Strategy is the strategy bumping the parent version to this version, empty for the root
+	Strategy string `json:"strategy,omitempty"`
+	Version  string `json:"version,omitempty"`
+	// Error is the reason the strategy can't bump the parent version
+	Error    string         `json:"error,omitempty"`
+	Children []*VersionNode `json:"children,omitempty"`
+}
+
+// label returns the version of the node, or ❌ if the strategy can't bump the parent version
+func (n *VersionNode) label() string {
+	if n.Error != "" {
+		return "❌"
+	}
+	return n.Version
+}
+
+// VersionTree returns the tree of potential versioning paths of a version: the versions every strategy and
+// pre-release channel bumps it to, expanded `depth` bumps deep. The depth must be between 1 and MaxShowDepth.
+func (vb *VersionBump) VersionTree(v *semver.SemanticVersion, depth int) (*VersionNode, error) {
+	if depth < 1 || depth > MaxShowDepth {
+		return nil, fmt.Errorf("invalid depth, the depth must be between 1 and %d: %d", MaxShowDepth, depth)
+	}
+	strategies, err := vb.showStrategies()
+	if err != nil {
+		return nil, err
+	}
+	root := &VersionNode{Version: v.String()}
+	vb.expandVersionNode(root, v, strategies, depth)
+	return root, nil
+}
+
+// expandVersionNode adds the versions each strategy bumps `v` to as the children of its node, `depth` bumps deep.
+func (vb *VersionBump) expandVersionNode(node *VersionNode, v *semver.SemanticVersion, strategies []semver.Strategy, depth int) {
+	if depth < 1 {
+		return
+	}
+	add := func(strategy string, bumped *semver.SemanticVersion, err error) {
+		child := &VersionNode{Strategy: strategy}
+		switch {
+		case err != nil:
+			child.Error = err.Error()
+		case bumped.String() == "":
+			child.Error = "no version"
+		default:
+			child.Version = bumped.String()
+			vb.expandVersionNode(child, bumped, strategies, depth-1)
+		}
+		node.Children = append(node.Children, child)
+	}
+
+	for _, strategy := range strategies {
+		if semver.Supports(strategy, v) {
+			bumped, err := strategy.Apply(v, vb.strategyConfig())
+			add(strategy.Name(), bumped, err)
+		}
+	}
+	// each pre-release channel is a branch of its own. Four-part versions don't have pre-release versions.
+	if !v.RootVersion().IsFourPart() {
+		for _, channel := range vb.Config.PreReleaseChannels {
+			bumped, err := v.BumpChannel(channel.GetLabel())
+			add("pre --channel "+channel.Name, bumped, err)
+		}
+	}
+}
+
+// showStrategies returns the strategies shown for a version: the registered strategies followed by the strategies of
+// the configuration. Strategies that don't apply to the version scheme are skipped when expanding the tree.
+func (vb *VersionBump) showStrategies() ([]semver.Strategy, error) {
+	configStrategies, err := vb.Config.GetStrategies()
+	if err != nil {
+		return nil, err
+	}
+	return append(semver.Strategies(), configStrategies...), nil
+}
+
+func (vb *VersionBump) Show(versionStr string) error {
+	format := vb.Options.ShowOpts.Format
+	if format == "" {
+		format = ShowFormatText
+	}
+	if !slices.Contains(ShowFormats, format) {
+		return fmt.Errorf("invalid format, expected one of %s: %s", strings.Join(ShowFormats, ", "), format)
+	}
+	var curVersionStr string
+	isProject := false
+	if versionStr != "" {
+		curVersionStr = versionStr
+	} else {
+		curVersionStr = vb.Config.Version
+		isProject = true
+	}
+	curVersion, err := vb.Config.ParseVersion(curVersionStr)
+	if err != nil {
+		return err
+	}
+	tree, err := vb.VersionTree(curVersion, vb.Options.ShowOpts.Depth)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case ShowFormatJSON:
+		b, err := json.MarshalIndent(tree, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	case ShowFormatMermaid:
+		fmt.Print(mermaidGraph(tree))
+	case ShowFormatDOT:
+		fmt.Print(dotGraph(tree))
+	default:
+		if !isProject {
+			logVerbose(vb.Options, fmt.Sprintf("Potential versioning paths for version: %s",
+				curVersion.String()))
+		} else {
+			logVerbose(vb.Options, fmt.Sprintf("Potential versioning paths for project version: %s",
+				curVersion.String()))
+		}
+		for _, child := range tree.Children {
+			if child.Error != "" {
+				logWarning(vb.Options, child.Error)
+			}
+		}
+		printColorOpts(vb.Options, strings.Join(textTree(tree.Version, tree), "\n")+"\n", ColorLightBlue)
+	}
+	return nil
+}
+
+// textTree returns the lines of the Unicode tree of a node, labelled with `label`, e.g.
+//
+//	1.2.3 ─┬─ major ─ 2.0.0
+//	       ╰─ minor ─ 1.3.0
+func textTree(label string, node *VersionNode) []string {
+	if len(node.Children) == 0 {
+		return []string{label}
+	}
+	indent := utils.PaddingString(utf8.RuneCountInString(label)+2, " ")
+	lines := make([]string, 0)
+	for i, child := range node.Children {
+		last := i == len(node.Children)-1
+		branch, continuation := indent+"├─ ", indent+"│  "
+		switch {
+		case i == 0 && last:
+			branch = label + " ─── "
+		case i == 0:
+			branch = label + " ─┬─ "
+		case last:
+			branch = indent + "╰─ "
+		}
+		if last {
+			continuation = indent + "   "
+		}
+		for j, line := range textTree(child.Strategy+" ─ "+child.label(), child) {
+			if j == 0 {
+				lines = append(lines, branch+line)
+			} else {
+				lines = append(lines, continuation+line)
+			}
+		}
+	}
+	return lines
+}
+
+// walkVersionTree calls `visit` for every edge of the tree, with the ids of the parent and child nodes. Nodes are
+// numbered in depth-first order, starting with the root as 0.
+func walkVersionTree(root *VersionNode, visit func(parentID int, childID int, child *VersionNode)) {
+	next := 1
+	var walk func(node *VersionNode, id int)
+	walk = func(node *VersionNode, id int) {
+		for _, child := range node.Children {
+			childID := next
+			next++
+			visit(id, childID, child)
+			walk(child, childID)
+		}
+	}
+	walk(root, 0)
+}
+
+// mermaidGraph returns the tree as a Mermaid flowchart
+func mermaidGraph(root *VersionNode) string {
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+	}
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	sb.WriteString(fmt.Sprintf("    n0[%s]\n", quote(root.Version)))
+	walkVersionTree(root, func(parentID int, childID int, child *VersionNode) {
+		sb.WriteString(fmt.Sprintf("    n%d -->|%s| n%d[%s]\n", parentID, quote(child.Strategy), childID,
+			quote(child.label())))
+	})
+	return sb.String()
+}
+
+// dotGraph returns the tree as a Graphviz DOT graph
+func dotGraph(root *VersionNode) string {
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+	}
+	var sb strings.Builder
+	sb.WriteString("digraph versions {\n")
+	sb.WriteString("    rankdir=LR;\n")
+	sb.WriteString(fmt.Sprintf("    n0 [label=%s];\n", quote(root.Version)))
+	walkVersionTree(root, func(parentID int, childID int, child *VersionNode) {
+		sb.WriteString(fmt.Sprintf("    n%d [label=%s];\n", childID, quote(child.label())))
+		sb.WriteString(fmt.Sprintf("    n%d -> n%d [label=%s];\n", parentID, childID, quote(child.Strategy)))
+	})
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionTree(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.PreReleaseChannels = []config.Channel{{Name: "nightly"}}
+	vb := &VersionBump{Config: *cfg}
+
+	tree, err := vb.VersionTree(semver.MustParse("1.2.3-rc"), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3-rc", tree.Version)
+	// the built-in strategies, except revision, and the channel
+	assert.Len(t, tree.Children, 13)
+	assert.Equal(t, "major", tree.Children[0].Strategy)
+	assert.Equal(t, "2.0.0", tree.Children[0].Version)
+	assert.Equal(t, "pre --channel nightly", tree.Children[12].Strategy)
+	assert.Equal(t, "1.2.3-nightly.1", tree.Children[12].Version)
+
+	// the last pre-release label can't be bumped
+	pre := tree.Children[7]
+	assert.Equal(t, "pre", pre.Strategy)
+	assert.Empty(t, pre.Version)
+	assert.NotEmpty(t, pre.Error)
+	assert.Empty(t, pre.Children)
+
+	// second-level bumps
+	newPreMinor := tree.Children[5]
+	assert.Equal(t, "new-pre-minor", newPreMinor.Strategy)
+	assert.Equal(t, "1.3.0-alpha", newPreMinor.Version)
+	assert.Equal(t, "pre", newPreMinor.Children[7].Strategy)
+	assert.Equal(t, "1.3.0-beta", newPreMinor.Children[7].Version)
+	assert.Empty(t, newPreMinor.Children[7].Children)
+
+	v, err := semver.ParseFourPartVersion("1.2.3.4")
+	assert.NoError(t, err)
+	fourPart, err := vb.VersionTree(v, 1)
+	assert.NoError(t, err)
+	assert.Len(t, fourPart.Children, 4)
+	assert.Equal(t, "revision", fourPart.Children[3].Strategy)
+	assert.Equal(t, "1.2.3.5", fourPart.Children[3].Version)
+
+	for _, depth := range []int{-1, 0, MaxShowDepth + 1} {
+		_, err := vb.VersionTree(v, depth)
+		assert.Error(t, err, "depth %d", depth)
+	}
+}
+
+func TestShowFormats(t *testing.T) {
+	tree := &VersionNode{Version: "1.2.3", Children: []*VersionNode{
+		{Strategy: "minor", Version: "1.3.0", Children: []*VersionNode{
+			{Strategy: "patch", Version: "1.3.1"},
+			{Strategy: "pre", Version: "1.3.0-alpha"},
+		}},
+		{Strategy: "release", Error: "not a pre-release"},
+		{Strategy: "patch", Version: "1.2.4", Children: []*VersionNode{
+			{Strategy: "patch", Version: "1.2.5"},
+		}},
+	}}
+
+	assert.Equal(t, []string{
+		"1.2.3 ─┬─ minor ─ 1.3.0 ─┬─ patch ─ 1.3.1",
+		"       │                 ╰─ pre ─ 1.3.0-alpha",
+		"       ├─ release ─ ❌",
+		"       ╰─ patch ─ 1.2.4 ─── patch ─ 1.2.5",
+	}, textTree(tree.Version, tree))
+
+	assert.Equal(t, `flowchart LR
+    n0["1.2.3"]
+    n0 -->|"minor"| n1["1.3.0"]
+    n1 -->|"patch"| n2["1.3.1"]
+    n1 -->|"pre"| n3["1.3.0-alpha"]
+    n0 -->|"release"| n4["❌"]
+    n0 -->|"patch"| n5["1.2.4"]
+    n5 -->|"patch"| n6["1.2.5"]
+`, mermaidGraph(tree))
+
+	assert.Equal(t, `digraph versions {
+    rankdir=LR;
+    n0 [label="1.2.3"];
+    n1 [label="1.3.0"];
+    n0 -> n1 [label="minor"];
+    n2 [label="1.3.1"];
+    n1 -> n2 [label="patch"];
+    n3 [label="1.3.0-alpha"];
+    n1 -> n3 [label="pre"];
+    n4 [label="❌"];
+    n0 -> n4 [label="release"];
+    n5 [label="1.2.4"];
+    n0 -> n5 [label="patch"];
+    n6 [label="1.2.5"];
+    n5 -> n6 [label="patch"];
+}
+`, dotGraph(tree))
+}
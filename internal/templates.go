@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ptgoetz/go-versionbump/internal/config"
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+)
+
+// TemplateData is the data model available to the git commit, tag and tag message templates.
+type TemplateData struct {
+	Old      *semver.SemanticVersion // The version before the bump
+	New      *semver.SemanticVersion // The version after the bump
+	Branch   string                  // The current git branch (empty if git is disabled)
+	Date     time.Time               // The time the bump was performed
+	Strategy string                  // The bump strategy, or "set" when setting an explicit version
+	User     string                  // The configured git user name (empty if git is disabled)
+	Files    []string                // The paths of the tracked files
+}
+
+// RenderTemplate renders a template with the given data (e.g. TemplateData for git templates). Templates are parsed
+// with config.ParseGitTemplate, so the legacy `{old}` and `{new}` placeholders are still supported.
+func RenderTemplate(name string, text string, data interface{}) (string, error) {
+	tmpl, err := config.ParseGitTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ptgoetz/go-versionbump/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	oldVersion, _ := semver.ParseSemVersion("1.2.3")
+	newVersion, _ := semver.ParseSemVersion("1.3.0-rc")
+	data := TemplateData{
+		Old:      oldVersion,
+		New:      newVersion,
+		Branch:   "main",
+		Date:     time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC),
+		Strategy: "new-pre-minor",
+		User:     "Jane Doe",
+		Files:    []string{"README.md", "versionbump.yaml"},
+	}
+
+	tests := []struct {
+		template   string
+		expected   string
+		shouldFail bool
+	}{
+		{"bump version {old} --> {new}", "bump version 1.2.3 --> 1.3.0-rc", false},
+		{"v{new}", "v1.3.0-rc", false},
+		{"v{{.New}}", "v1.3.0-rc", false},
+		{"{{.Old}} -> {{.New}} on {{.Branch}}", "1.2.3 -> 1.3.0-rc on main", false},
+		{"release-{{.New.RootVersion.Major}}.{{.New.RootVersion.Minor}}", "release-1.3", false},
+		{"{{upper .New.PreReleaseVersion.Label}}", "RC", false},
+		{"{{date \"2006-01-02\" .Date}} {{.Strategy}} by {{.User}}", "2024-10-16 new-pre-minor by Jane Doe", false},
+		{"updated {{join \", \" .Files}}", "updated README.md, versionbump.yaml", false},
+		{"{{.Missing}}", "", true},
+		{"{{.New", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			result, err := RenderTemplate("test", test.template, data)
+			if test.shouldFail {
+				assert.Error(t, err, "expected an error for template %s", test.template)
+			} else {
+				assert.NoError(t, err, "unexpected error for template %s", test.template)
+				assert.Equal(t, test.expected, result)
+			}
+		})
+	}
+}
+
+func TestExtractVersion(t *testing.T) {
+	tests := []struct {
+		template   string
+		value      string
+		expected   string
+		shouldFail bool
+	}{
+		{"v{new}", "v1.2.3", "1.2.3", false},
+		{"v{{.New}}", "v1.2.3", "1.2.3", false},
+		{"release-{{ .New }}", "release-1.2.3-alpha", "1.2.3-alpha", false},
+		{"v{new}", "1.2.3", "", true},
+		{"a{new}a", "a", "", true},
+		{"release", "release", "", true},
+		{"v{{.New | upper}}", "v1.2.3", "", true},
+		{"v{{.New.RootVersion}}", "v1.2.3", "", true},
+		{"{new}-{{.New}}", "1.2.3-1.2.3", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.template+"/"+test.value, func(t *testing.T) {
+			result, err := ExtractVersion(test.template, test.value)
+			if test.shouldFail {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, result)
+			}
+		})
+	}
+}
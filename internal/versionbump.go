@@ -3,17 +3,17 @@ package internal
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
-	"text/template"
+	"time"
 
 	"github.com/ptgoetz/go-versionbump/internal/config"
 	"github.com/ptgoetz/go-versionbump/internal/git"
 	"github.com/ptgoetz/go-versionbump/internal/utils"
 	vbu "github.com/ptgoetz/go-versionbump/internal/utils"
 	"github.com/ptgoetz/go-versionbump/pkg/semver"
-	"gopkg.in/yaml.v2"
 )
 
 const Version = "0.7.1"
@@ -23,10 +23,21 @@ type VersionBump struct {
 	Config    config.Config
 	Options   config.Options
 	ParentDir string
+	// the build metadata of the new version, see buildMetadata
+	buildMetadataIdentifiers []string
+	buildMetadataResolved    bool
 }
 
 // NewVersionBump creates a new VersionBump instance.
 func NewVersionBump(options config.Options) (*VersionBump, error) {
+	// without an explicit config file, search upwards from the working directory
+	if options.ConfigPath == "" {
+		configPath, err := config.FindConfig(".")
+		if err != nil {
+			return nil, err
+		}
+		options.ConfigPath = configPath
+	}
 
 	cfg, parentDir, err := config.LoadConfig(options.ConfigPath)
 	if err != nil {
@@ -39,28 +50,92 @@ func NewVersionBump(options config.Options) (*VersionBump, error) {
 		ParentDir: parentDir,
 	}
 
+	if options.Channel != "" {
+		if _, err := cfg.GetChannel(options.Channel); err != nil {
+			return nil, err
+		}
+	}
+	// the strategies of a chain are resolved before the version is bumped
+	if options.BumpPart != "" && !options.IsResetVersion() {
+		if _, err := cfg.GetStrategyChain(options.BumpPart.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.IsLatestTagSource() {
+		if options.NoGit {
+			return nil, fmt.Errorf("version-source latest-tag requires git")
+		}
+		latest, err := vb.latestTaggedVersion()
+		if err != nil {
+			return nil, fmt.Errorf("error reading version from git tags: %v", err)
+		}
+		if latest != nil {
+			vb.Config.Version = latest.String()
+		} else {
+			// no release has been tagged yet, so start from the initial version
+			vb.Config.Version = config.DefaultVersion
+			if cfg.IsFourPart() {
+				vb.Config.Version = config.DefaultFourPartVersion
+			}
+		}
+	}
+
 	return vb, nil
 }
 
 func (vb *VersionBump) GetOldVersion() string {
-	if !semver.ValidateSemVersion(vb.Config.Version) {
+	oldVersion, err := vb.Config.ParseVersion(vb.Config.Version)
+	if err != nil {
 		logFatal(vb.Options, fmt.Sprintf("Failed to parse semantic version string for old version: %s", vb.Config.Version))
 	}
-	oldVersion, _ := semver.ParseSemVersion(vb.Config.Version)
 	return oldVersion.String()
 }
 
 func (vb *VersionBump) GetNewVersion() string {
 	if vb.Options.IsResetVersion() {
-		v, err := semver.ParseSemVersion(vb.Options.ResetVersion)
+		v, err := vb.Config.ParseVersion(vb.Options.ResetVersion)
 		if err != nil {
 			logFatal(vb.Options, fmt.Sprintf("Failed to parse semantic version string for reset version: %s", vb.Options.ResetVersion))
 		}
 		return v.String()
 	}
 	oldVersionStr := vb.GetOldVersion()
-	oldVersion, _ := semver.ParseSemVersion(oldVersionStr)
-	newVersion, _ := oldVersion.Bump(vb.Options.BumpPart, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
+	oldVersion, _ := vb.Config.ParseVersion(oldVersionStr)
+	var newVersion *semver.SemanticVersion
+	if vb.Options.Channel != "" {
+		channel, err := vb.Config.GetChannel(vb.Options.Channel)
+		if err != nil {
+			logFatal(vb.Options, err.Error())
+		}
+		newVersion, err = oldVersion.BumpChannel(channel.GetLabel())
+		if err != nil {
+			logFatal(vb.Options, err.Error())
+		}
+	} else {
+		strategy, err := vb.Config.GetStrategyChain(vb.Options.BumpPart.String())
+		if err != nil {
+			logFatal(vb.Options, err.Error())
+		}
+		newVersion, err = strategy.Apply(oldVersion, vb.strategyConfig())
+		if err != nil {
+			logFatal(vb.Options, err.Error())
+		}
+	}
+
+	build, err := vb.buildMetadata()
+	if err != nil {
+		logFatal(vb.Options, err.Error())
+	}
+	if build != nil && vb.Config.IsFourPart() {
+		logFatal(vb.Options, "four-part versions don't have build metadata")
+	}
+	if build != nil {
+		newVersion, err = newVersion.WithBuild(build)
+		if err != nil {
+			logFatal(vb.Options, err.Error())
+		}
+	}
 	return newVersion.String()
 }
 
@@ -79,115 +154,9 @@ func (vb *VersionBump) ShowVersion() {
 	fmt.Println(vb.Config.Version)
 }
 
-func checkBumpError(vb *VersionBump, v *semver.SemanticVersion, err error) string {
-	if err != nil {
-		logWarning(vb.Options, err.Error())
-		return "❌"
-	} else {
-		return v.String()
-	}
-}
-
-func (vb *VersionBump) Show(versionStr string) error {
-	var curVersionStr string
-	isProject := false
-	if versionStr != "" {
-		curVersionStr = versionStr
-	} else {
-		curVersionStr = vb.Config.Version
-		isProject = true
-	}
-	curVersion, err := semver.ParseSemVersion(curVersionStr)
-	if err != nil {
-		return err
-	}
-
-	if !isProject {
-		logVerbose(vb.Options, fmt.Sprintf("Potential versioning paths for version: %s",
-			curVersion.String()))
-	} else {
-		logVerbose(vb.Options, fmt.Sprintf("Potential versioning paths for project version: %s",
-			curVersion.String()))
-	}
-	// we now know we have a valid version
-	majorVersion, err := curVersion.Bump(semver.Major, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	majorVersionStr := checkBumpError(vb, majorVersion, err)
-	minorVersion, err := curVersion.Bump(semver.Minor, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	minorVersionStr := checkBumpError(vb, minorVersion, err)
-	patchVersion, err := curVersion.Bump(semver.Patch, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	patchVersionStr := checkBumpError(vb, patchVersion, err)
-
-	releaseVersion, err := curVersion.Bump(semver.Release, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	releaseVersionStr := checkBumpError(vb, releaseVersion, err)
-
-	prNextVersion, err := curVersion.Bump(semver.PreRelease, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	prNextVersionStr := checkBumpError(vb, prNextVersion, err)
-
-	prMajorVersion, err := curVersion.Bump(semver.PreReleaseMajor, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	prMajorVersionStr := checkBumpError(vb, prMajorVersion, err)
-	prMinorVersion, err := curVersion.Bump(semver.PreReleaseMinor, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	prMinorVersionStr := checkBumpError(vb, prMinorVersion, err)
-	prPatchVersion, err := curVersion.Bump(semver.PreReleasePatch, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	prPatchVersionStr := checkBumpError(vb, prPatchVersion, err)
-
-	prNewMajorVersion, err := curVersion.Bump(semver.PreReleaseNewMajor, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	prNewMajorVersionStr := checkBumpError(vb, prNewMajorVersion, err)
-	prNewMinorVersion, err := curVersion.Bump(semver.PreReleaseNewMinor, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	prNewMinorVersionStr := checkBumpError(vb, prNewMinorVersion, err)
-	prNewPatchVersion, err := curVersion.Bump(semver.PreReleaseNewPatch, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	prNewPatchVersionStr := checkBumpError(vb, prNewPatchVersion, err)
-
-	prBuildVersion, err := curVersion.Bump(semver.PreReleaseBuild, vb.Config.PreReleaseLabels, vb.Config.BuildLabel)
-	prBuildVersionStr := checkBumpError(vb, prBuildVersion, err)
-
-	if prNextVersionStr == "" {
-		prNextVersionStr = "❌"
-	}
-
-	padLen := len(curVersion.String())
-	padding := utils.PaddingString(padLen, " ")
-
-	tree := fmt.Sprintf(
-		`%s ─┬─ major ─ %s
-  %s├─ minor ─ %s
-  %s├─ patch ─ %s
-  %s├─ release ─ %s
-  %s├─ new-pre-major ─ %s
-  %s├─ new-pre-minor ─ %s
-  %s├─ new-pre-patch ─ %s
-  %s├─ pre ─ %s
-  %s├─ pre-major ─ %s
-  %s├─ pre-minor ─ %s
-  %s├─ pre-patch ─ %s
-  %s╰─ pre-build ─ %s
-`,
-		curVersion.String(),
-		majorVersionStr,
-		padding,
-		minorVersionStr,
-		padding,
-		patchVersionStr,
-		padding,
-		releaseVersionStr,
-		padding,
-		prNewMajorVersionStr,
-		padding,
-		prNewMinorVersionStr,
-		padding,
-		prNewPatchVersionStr,
-		padding,
-		prNextVersionStr,
-		padding,
-		prMajorVersionStr,
-		padding,
-		prMinorVersionStr,
-		padding,
-		prPatchVersionStr,
-		padding,
-		prBuildVersionStr)
-
-	printColorOpts(vb.Options, tree, ColorLightBlue)
-	return nil
+// strategyConfig returns the configuration strategies are applied with.
+func (vb *VersionBump) strategyConfig() semver.StrategyConfig {
+	return semver.StrategyConfig{PreReleaseLabels: vb.Config.GetSortedLabels(), BuildLabel: vb.Config.BuildLabel}
 }
 
 func (vb *VersionBump) GitTagHistory() error {
@@ -206,17 +175,26 @@ func (vb *VersionBump) GitTagHistory() error {
 }
 
 func (vb *VersionBump) LatestVersion() error {
-	versions, err := vb.GetSortedVersions()
+	latest, err := vb.latestTaggedVersion()
 	if err != nil {
 		return err
 	}
-	if len(versions) == 0 {
+	if latest == nil {
 		return fmt.Errorf("no versions found")
 	}
-	fmt.Println(versions[0].String())
+	fmt.Println(latest.String())
 	return nil
 }
 
+// latestTaggedVersion returns the highest version tagged in git, or nil if no tag matches the git tag template.
+func (vb *VersionBump) latestTaggedVersion() (*semver.SemanticVersion, error) {
+	versions, err := vb.GetSortedVersions()
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+	return versions[0], nil
+}
+
 func (vb *VersionBump) GetSortedVersions() ([]*semver.SemanticVersion, error) {
 	tags, err := git.GetTags(vb.ParentDir)
 	if err != nil {
@@ -229,7 +207,7 @@ func (vb *VersionBump) GetSortedVersions() ([]*semver.SemanticVersion, error) {
 			logVerbose(vb.Options, fmt.Sprintf("Error extracting version from tag: %s", err.Error()))
 			continue
 		}
-		v, err := semver.ParseSemVersion(vStr)
+		v, err := vb.Config.ParseVersion(vStr)
 		if err == nil {
 			versions = append(versions, v)
 		} else {
@@ -237,51 +215,181 @@ func (vb *VersionBump) GetSortedVersions() ([]*semver.SemanticVersion, error) {
 		}
 
 	}
-	semver.SortVersions(versions)
+	semver.SortVersionsWithLabels(versions, vb.Config.GetSortedLabels())
 	return versions, nil
 }
 
+// ExtractVersion extracts the version string from a value rendered with the given tag template. The template must
+// reference the new version once, with either the `{new}` placeholder or a bare `{{.New}}` template action (see
+// config.SplitTagTemplate).
 func ExtractVersion(template, value string) (string, error) {
-	version := "{new}"
-	idx := strings.Index(template, version)
-	idx2 := idx + len(version)
-	right := template[idx2:]
-	left := template[:idx]
-	if !strings.Contains(value, left) || !strings.Contains(value, right) {
-		return "", fmt.Errorf("value '%s' does not match template '%s'", value, template)
+	prefix, suffix, err := config.SplitTagTemplate(template)
+	if err != nil {
+		return "", fmt.Errorf("template '%s' %w", template, err)
 	}
-
-	proposed := value[idx : len(value)-len(right)]
-	newValue := strings.ReplaceAll(template, version, proposed)
-	if len(newValue) != len(value) {
+	if len(value) <= len(prefix)+len(suffix) || !strings.HasPrefix(value, prefix) || !strings.HasSuffix(value, suffix) {
 		return "", fmt.Errorf("value '%s' does not match template '%s'", value, template)
 	}
-
-	return proposed, nil
+	return value[len(prefix) : len(value)-len(suffix)], nil
 }
 
 func (vb *VersionBump) ShowEffectiveConfig() error {
+	format := config.FormatYAML
+	if vb.Options.Format != "" {
+		var err error
+		format, err = config.ParseFormat(vb.Options.Format)
+		if err != nil {
+			return err
+		}
+	}
 	logVerbose(vb.Options, fmt.Sprintf("Config file: %s", vb.Options.ConfigPath))
 	logVerbose(vb.Options, fmt.Sprintf("Project root: %s", vb.ParentDir))
-	logVerbose(vb.Options, "Effective Configuration YAML:")
+	logVerbose(vb.Options, fmt.Sprintf("Effective Configuration %s:", strings.ToUpper(format.String())))
 
 	conf := &vb.Config
-	b, err := yaml.Marshal(conf)
+	b, err := format.Marshal(conf)
 	if err != nil {
 		return err
 	}
 	printColorOpts(vb.Options, string(b), ColorLightBlue)
+
+	// show where each value came from when the config is merged from multiple files or overridden
+	if len(conf.Extends) > 0 || conf.HasEnvOverrides() {
+		logVerbose(vb.Options, "Configuration Sources:")
+		for _, key := range conf.SourceKeys() {
+			logVerbose(vb.Options, fmt.Sprintf("  %s: %s", key, conf.Source(key)))
+		}
+	}
+	return nil
+}
+
+// SetConfigValue sets a setting in the configuration file, preserving its comments and formatting.
+func SetConfigValue(opts config.Options, key string, value string) error {
+	if opts.ConfigPath == "" {
+		configPath, err := config.FindConfig(".")
+		if err != nil {
+			return err
+		}
+		opts.ConfigPath = configPath
+	}
+
+	if err := config.SetValue(opts.ConfigPath, key, value); err != nil {
+		return fmt.Errorf("error setting %s: %w", key, err)
+	}
+	logVerbose(opts, fmt.Sprintf("Set %s to \"%s\" in %s", key, value, opts.ConfigPath))
+	return nil
+}
+
+// ValidateConfig checks the configuration file and reports every problem found.
+func ValidateConfig(opts config.Options) error {
+	if opts.ConfigPath == "" {
+		configPath, err := config.FindConfig(".")
+		if err != nil {
+			return err
+		}
+		opts.ConfigPath = configPath
+	}
+
+	problems := config.Validate(opts.ConfigPath)
+	for _, problem := range problems {
+		printColorOpts(opts, fmt.Sprintf("%s\n", problem), ColorRed)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("found %d problem(s) in %s", len(problems), opts.ConfigPath)
+	}
+	logVerbose(opts, fmt.Sprintf("Configuration is valid: %s", opts.ConfigPath))
+	return nil
+}
+
+// ShowSchema prints the JSON Schema of the configuration file.
+func ShowSchema() error {
+	b, err := config.FormatJSON.Marshal(config.Schema())
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(b))
 	return nil
 }
 
 func InitVersionBumpProject(opts config.Options) error {
+	// the format is either explicit, or detected from the file name
+	format := config.FormatFromPath(opts.InitOpts.File)
+	if opts.InitOpts.Format != "" {
+		var err error
+		format, err = config.ParseFormat(opts.InitOpts.Format)
+		if err != nil {
+			return err
+		}
+	}
+	if opts.InitOpts.File == "" {
+		opts.InitOpts.File = "versionbump." + format.Extension()
+	}
+
 	// check to see if a configuration file already exists
 	if utils.FileExists(opts.InitOpts.File) {
 		return fmt.Errorf("configuration file already exists: %s", opts.InitOpts.File)
 	}
 
 	conf := config.NewConfig()
+	if opts.InitOpts.InitialVersion != "" {
+		conf.Version = opts.InitOpts.InitialVersion
+	}
+	if len(opts.InitOpts.PreReleaseLabels) > 0 {
+		conf.PreReleaseLabels = opts.InitOpts.PreReleaseLabels
+	}
+	if opts.InitOpts.BuildLabel != "" {
+		conf.BuildLabel = opts.InitOpts.BuildLabel
+	}
+	conf.GitCommit = opts.InitOpts.GitCommit
+	conf.GitTag = opts.InitOpts.GitTag
+	conf.GitSign = opts.InitOpts.GitSign
+
+	if opts.InitOpts.NoInteractive {
+		if !semver.ValidatePreReleaseLabels(conf.PreReleaseLabels) {
+			return fmt.Errorf("invalid pre-release labels, labels must be alphabetic: %v", conf.PreReleaseLabels)
+		}
+		if !semver.ValidateBuildLabel(conf.BuildLabel) {
+			return fmt.Errorf("invalid build label, the label must be alphanumeric: %s", conf.BuildLabel)
+		}
+		if !semver.ValidateSemVersion(conf.Version) {
+			return fmt.Errorf("invalid initial version: %s", conf.Version)
+		}
+	} else {
+		promptInitConfig(conf)
+	}
+
+	if opts.InitOpts.ScanDirectory {
+		files, err := ScanVersionFiles(filepath.Dir(opts.InitOpts.File), conf.Version)
+		if err != nil {
+			return fmt.Errorf("error scanning for version %s: %w", conf.Version, err)
+		}
+		for _, file := range files {
+			if !opts.InitOpts.NoInteractive {
+				printColor(fmt.Sprintf("Found version %s in %s:\n", conf.Version, file.Path), ColorLightGray)
+				for _, pattern := range file.Replace {
+					printColor(fmt.Sprintf("  %s\n", pattern), ColorLightGray)
+				}
+				if !promptUserConfirm(fmt.Sprintf("Track %s?", file.Path)) {
+					continue
+				}
+			}
+			conf.Files = append(conf.Files, file)
+		}
+	}
+
+	// create the configuration file
+	f, err := os.Create(opts.InitOpts.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return format.RenderConfig(f, conf)
+}
 
+// promptInitConfig prompts the user for the settings of a new configuration file. The current values are the
+// defaults.
+func promptInitConfig(conf *config.Config) {
 	// prompt the user for pre-release labels
 	prLabels := promptUserForValue(
 		"Enter pre-release labels (comma-separated)",
@@ -297,9 +405,13 @@ func InitVersionBumpProject(opts config.Options) error {
 	conf.BuildLabel = buildLabel
 
 	// prompt the user for the initial version
-	initVersionStr := promptUserForValue("Enter the initial version", "0.0.0", semver.ValidateSemVersion)
+	initVersionStr := promptUserForValue("Enter the initial version", conf.Version, semver.ValidateSemVersion)
 	conf.Version = initVersionStr
 
+	// git features enabled with flags don't need to be confirmed
+	if conf.IsGitRequired() {
+		return
+	}
 	gitAvail, _ := git.IsGitAvailable()
 	if gitAvail {
 		if promptUserConfirm("Git is installed on this system. \nDo you want to enable Git features?") {
@@ -309,63 +421,78 @@ func InitVersionBumpProject(opts config.Options) error {
 			}
 		}
 	}
-
-	//
-	tmpl, err := template.New("yaml").Parse(config.DefaultConfigTemplate)
-	if err != nil {
-		panic(err)
-	}
-
-	// create the configuration file
-	f, err := os.Create(opts.InitOpts.File)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	err = tmpl.Execute(f, conf)
-	if err != nil {
-		panic(err)
-	}
-	//
-	return nil
 }
 
 func (vb *VersionBump) GitMetadata() (*config.GitMeta, error) {
-	var commitMessageTemplate string
-	if vb.Config.GitCommitTemplate != "" {
-		commitMessageTemplate = vb.Config.GitCommitTemplate
-	} else {
+	data := vb.templateData()
+
+	commitMessageTemplate := vb.Config.GitCommitTemplate
+	if commitMessageTemplate == "" {
 		commitMessageTemplate = config.DefaultGitCommitTemplate
 	}
-	commitMessage := utils.ReplaceInString(commitMessageTemplate, "{old}", vb.GetOldVersion())
-	commitMessage = utils.ReplaceInString(commitMessage, "{new}", vb.GetNewVersion())
+	commitMessage, err := RenderTemplate("git-commit", commitMessageTemplate, data)
+	if err != nil {
+		return nil, err
+	}
 
-	var tagTemplate string
-	if vb.Config.GitTagTemplate != "" {
-		tagTemplate = vb.Config.GitTagTemplate
-	} else {
+	tagTemplate := vb.Config.GitTagTemplate
+	if tagTemplate == "" {
 		tagTemplate = config.DefaultGitTagTemplate
 	}
-	tagName := utils.ReplaceInString(tagTemplate, "{old}", vb.GetOldVersion())
-	tagName = utils.ReplaceInString(tagName, "{new}", vb.GetNewVersion())
+	tagName, err := RenderTemplate("git-tag", tagTemplate, data)
+	if err != nil {
+		return nil, err
+	}
 
-	var tagMessageTemplate string
-	if vb.Config.GitTagMessageTemplate != "" {
-		tagMessageTemplate = vb.Config.GitTagMessageTemplate
-	} else {
+	tagMessageTemplate := vb.Config.GitTagMessageTemplate
+	if tagMessageTemplate == "" {
 		tagMessageTemplate = config.DefaultGitTagMessageTemplate
 	}
-	tagMessage := utils.ReplaceInString(tagMessageTemplate, "{old}", vb.GetOldVersion())
-	tagMessage = utils.ReplaceInString(tagMessage, "{new}", vb.GetNewVersion())
+	tagMessage, err := RenderTemplate("git-tag-message", tagMessageTemplate, data)
+	if err != nil {
+		return nil, err
+	}
 
 	return &config.GitMeta{
+		OldVersion:    data.Old.String(),
+		NewVersion:    data.New.String(),
 		CommitMessage: commitMessage,
 		TagMessage:    tagMessage,
 		TagName:       tagName,
 	}, nil
 }
 
+// templateData collects the data model used to render the git templates.
+func (vb *VersionBump) templateData() TemplateData {
+	oldVersion, _ := vb.Config.ParseVersion(vb.GetOldVersion())
+	newVersion, _ := vb.Config.ParseVersion(vb.GetNewVersion())
+
+	strategy := vb.Options.BumpPart.String()
+	if vb.Options.IsResetVersion() {
+		strategy = "set"
+	}
+
+	files := make([]string, 0, len(vb.Config.Files))
+	for _, file := range vb.Config.Files {
+		files = append(files, file.Path)
+	}
+
+	data := TemplateData{
+		Old:      oldVersion,
+		New:      newVersion,
+		Date:     time.Now(),
+		Strategy: strategy,
+		Files:    files,
+	}
+
+	// branch and user are informational, so failing to look them up is not fatal
+	if !vb.Options.NoGit {
+		data.Branch, _ = git.GetCurrentBranch(vb.ParentDir)
+		data.User, _ = git.GetUserName(vb.ParentDir)
+	}
+	return data
+}
+
 func (vb *VersionBump) gitPreFlight() {
 	if vb.Options.NoGit {
 		return
@@ -442,7 +569,9 @@ func (vb *VersionBump) gitPreFlight() {
 
 	// check if the Git repository has pending changes
 	isDirty, _ := git.HasPendingChanges(vb.ParentDir)
-	if isDirty {
+	if isDirty && vb.allowsPendingChanges() {
+		logWarning(vb.Options, "The Git repository has pending changes, which are marked by the dirty build metadata.")
+	} else if isDirty {
 		logFatal(vb.Options, "The Git repository has pending changes. Please commit or stash them before proceeding.")
 	}
 
@@ -478,6 +607,9 @@ func (vb *VersionBump) preamble() {
 	logVerbose(vb.Options, fmt.Sprintf("VersionBump %s", Version))
 	logVerbose(vb.Options, fmt.Sprintf("Configuration file: %s", vb.Options.ConfigPath))
 	logVerbose(vb.Options, fmt.Sprintf("Project root directory: %s", vb.ParentDir))
+	if vb.Config.IsLatestTagSource() {
+		logVerbose(vb.Options, fmt.Sprintf("Version source: latest git tag matching '%s'", vb.Config.GitTagTemplate))
+	}
 }
 
 func (vb *VersionBump) logTrackedFiles() {
@@ -509,8 +641,8 @@ func (vb *VersionBump) gitCommit() {
 		}
 	}
 
-	// commit changes
-	if vb.Config.GitCommit {
+	// commit changes (when the version only lives in git tags there may be nothing to commit)
+	if vb.Config.GitCommit && len(vb.Config.Files) > 0 {
 		logVerbose(vb.Options, "Committing changes...")
 		err := git.CommitChanges(vb.ParentDir, gitMeta.CommitMessage, vb.Config.GitSign)
 		if err != nil {
@@ -536,26 +668,44 @@ func (vb *VersionBump) gitCommit() {
 
 // bumpPreflight performs a pre-flight check for the Version bump operation.
 func (vb *VersionBump) bumpPreflight() {
-	if !vb.Options.IsResetVersion() {
+	if vb.Options.Channel != "" {
+		logVerbose(vb.Options, fmt.Sprintf("Bumping version part: %s (channel: %s)", vb.Options.BumpPart, vb.Options.Channel))
+	} else if !vb.Options.IsResetVersion() {
 		logVerbose(vb.Options, fmt.Sprintf("Bumping version part: %s", vb.Options.BumpPart))
 	} else {
 		logVerbose(vb.Options, fmt.Sprintf("Resetting version to: %s", vb.GetNewVersion()))
 	}
 	logVerbose(vb.Options, fmt.Sprintf("Will bump version %s --> %s", vb.GetOldVersion(), vb.GetNewVersion()))
+	for _, warning := range vb.bumpTransitionWarnings() {
+		logWarning(vb.Options, warning)
+	}
+	vb.versionPolicyPreflight()
 
 	// log what changes will be made to each file
 	for _, file := range vb.Config.Files {
+		oldVersion, newVersion, err := vb.fileVersions(file)
+		if err != nil {
+			logFatal(vb.Options, fmt.Sprintf("Unable to format the version of file %s: %v\n", file.Path, err))
+		}
+		if file.Key != "" {
+			logVerbose(vb.Options, file.Path)
+			value, err := config.ReadKey(config.ResolvePath(vb.ParentDir, file.Path), file.Key)
+			if err != nil {
+				logFatal(vb.Options, fmt.Sprintf("Unable to read key '%s' in file %s: %v\n", file.Key, file.Path, err))
+			}
+			logVerbose(vb.Options, fmt.Sprintf("      Key: \"%s\"", file.Key))
+			logVerbose(vb.Options, fmt.Sprintf("    Value: \"%s\" --> \"%s\"", value, newVersion))
+		}
 		for _, replace := range file.Replace {
-			find := vbu.ReplaceInString(replace, "{version}", vb.GetOldVersion())
-			replace := vbu.ReplaceInString(replace, "{version}", vb.GetNewVersion())
+			find := vbu.ReplaceInString(replace, "{version}", oldVersion)
+			replace := vbu.ReplaceInString(replace, "{version}", newVersion)
 
 			logVerbose(vb.Options, file.Path)
 			logVerbose(vb.Options, fmt.Sprintf("     Find: \"%s\"", find))
 			logVerbose(vb.Options, fmt.Sprintf("  Replace: \"%s\"", replace))
-			count, err := vbu.CountStringsInFile(path.Join(vb.ParentDir, file.Path), find)
+			count, err := vbu.CountStringsInFile(config.ResolvePath(vb.ParentDir, file.Path), find)
 			if err != nil {
-				fmt.Println(fmt.Errorf("error getting replacement count: a%v", err))
-				os.Exit(1)
+				logFatal(vb.Options, fmt.Sprintf("error getting replacement count: %v", err))
 			}
 			if count > 0 {
 				logVerbose(vb.Options, fmt.Sprintf("    Found %d replacement(s)", count))
@@ -566,24 +716,50 @@ func (vb *VersionBump) bumpPreflight() {
 	}
 }
 
+// fileVersions returns the old and new versions in the version dialect of the file.
+func (vb *VersionBump) fileVersions(file config.VersionedFile) (string, string, error) {
+	dialect := file.GetDialect()
+	if dialect == semver.DialectSemVer {
+		return vb.GetOldVersion(), vb.GetNewVersion(), nil
+	}
+	var versions []string
+	for _, version := range []string{vb.GetOldVersion(), vb.GetNewVersion()} {
+		v, err := vb.Config.ParseVersion(version)
+		if err != nil {
+			return "", "", err
+		}
+		formatted, err := v.ToDialect(dialect)
+		if err != nil {
+			return "", "", err
+		}
+		versions = append(versions, formatted)
+	}
+	return versions[0], versions[1], nil
+}
+
 // makeChanges updates the Version in the files.
 func (vb *VersionBump) makeChanges() {
 	// at this point we have already checked the config and there are no errors
 	for _, file := range vb.Config.Files {
+		resolvedPath := config.ResolvePath(vb.ParentDir, file.Path)
+		oldVersion, newVersion, err := vb.fileVersions(file)
+		if err != nil {
+			logFatal(vb.Options, fmt.Sprintf("Unable to format the version of file %s: %v\n", file.Path, err))
+		}
+		if file.Key != "" {
+			err := config.WriteKey(resolvedPath, file.Key, newVersion)
+			if err != nil {
+				logFatal(vb.Options, fmt.Sprintf("error updating file %s: %v", file.Path, err))
+			}
+			logVerbose(vb.Options, fmt.Sprintf("Updated file: %s", file.Path))
+		}
 		for _, replace := range file.Replace {
-			find := vbu.ReplaceInString(replace, "{version}", vb.GetOldVersion())
-			replace := vbu.ReplaceInString(replace, "{version}", vb.GetNewVersion())
+			find := vbu.ReplaceInString(replace, "{version}", oldVersion)
+			replace := vbu.ReplaceInString(replace, "{version}", newVersion)
 
-			var resolvedPath string
-			if path.IsAbs(file.Path) {
-				resolvedPath = file.Path
-			} else {
-				resolvedPath = path.Join(vb.ParentDir, file.Path)
-			}
 			err := vbu.ReplaceInFile(resolvedPath, find, replace)
 			if err != nil {
-				fmt.Println(fmt.Errorf("error updating file %s: a%v", file.Path, err))
-				os.Exit(1)
+				logFatal(vb.Options, fmt.Sprintf("error updating file %s: %v", file.Path, err))
 			}
 			logVerbose(vb.Options, fmt.Sprintf("Updated file: %s", file.Path))
 		}
@@ -610,7 +786,11 @@ func promptUserConfirm(prompt string) bool {
 		// Print the prompt and read the user's input
 		printColor(fmt.Sprintf("%s [y/N]: ", prompt), ColorLightBlue)
 		input, err := reader.ReadString('\n')
-		if err != nil {
+		if err == io.EOF && input == "" {
+			// there is no more input, e.g. stdin is not a terminal
+			fmt.Println()
+			return false
+		} else if err != nil && err != io.EOF {
 			printColor("Error reading input. Please try again.", ColorYellow)
 			continue
 		}
@@ -642,7 +822,11 @@ func promptUserForValue(prompt string, defaultValue string, validator func(strin
 		// Print the prompt and read the user's input
 		printColor(fmt.Sprintf("%s [%s]: ", prompt, defaultValue), ColorLightBlue)
 		input, err := reader.ReadString('\n')
-		if err != nil {
+		if err == io.EOF && input == "" {
+			// there is no more input, e.g. stdin is not a terminal
+			fmt.Println()
+			return defaultValue
+		} else if err != nil && err != io.EOF {
 			printColor("Error reading input. Please try again.\n", ColorYellow)
 			continue
 		}
@@ -2,7 +2,9 @@ package internal
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ptgoetz/go-versionbump/internal/config"
@@ -66,3 +68,119 @@ func TestGitMetadata(t *testing.T) {
 	assert.Equal(t, "v1.0.1", gitMeta.TagName)
 	assert.Equal(t, "Tagging version 1.0.1", gitMeta.TagMessage)
 }
+
+func TestGitMetadataGoTemplates(t *testing.T) {
+	vb := &VersionBump{
+		Config: config.Config{
+			Version:               "1.0.0",
+			GitCommitTemplate:     "Bump {{.Old}} to {{.New}} ({{.Strategy}})",
+			GitTagTemplate:        "release-{{.New.RootVersion.Major}}.{{.New.RootVersion.Minor}}.{{.New.RootVersion.Patch}}",
+			GitTagMessageTemplate: "{{upper \"release\"}} {new}",
+		},
+		Options: config.Options{
+			BumpPart: "minor",
+			NoGit:    true,
+		},
+	}
+
+	gitMeta, err := vb.GitMetadata()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bump 1.0.0 to 1.1.0 (minor)", gitMeta.CommitMessage)
+	assert.Equal(t, "release-1.1.0", gitMeta.TagName)
+	assert.Equal(t, "RELEASE 1.1.0", gitMeta.TagMessage)
+}
+
+func TestNewVersionBumpTagMode(t *testing.T) {
+	dir, err := os.MkdirTemp("", "versionBumpTagModeTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	if err := os.WriteFile(filePath, []byte("version-source:\n  latest-tag: true\ngit-commit: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+
+	newGitRepo(t, dir)
+
+	options := config.Options{
+		ConfigPath: filePath,
+		BumpPart:   "minor",
+	}
+
+	// without any tags, the initial version is used
+	vb, err := NewVersionBump(options)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0", vb.GetOldVersion())
+	assert.Equal(t, "0.1.0", vb.GetNewVersion())
+
+	runGit(t, dir, "tag", "v1.0.0")
+	runGit(t, dir, "tag", "v1.2.0-beta")
+	runGit(t, dir, "tag", "v1.1.5")
+	runGit(t, dir, "tag", "not-a-version")
+
+	vb, err = NewVersionBump(options)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.0-beta", vb.GetOldVersion())
+	assert.Equal(t, "1.3.0", vb.GetNewVersion())
+
+	options.NoGit = true
+	_, err = NewVersionBump(options)
+	assert.Error(t, err)
+}
+
+func TestFileVersions(t *testing.T) {
+	dir, err := os.MkdirTemp("", "fileVersionsTest")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "versionbump.yaml")
+	yamlContent := `
+  version: "1.2.0-alpha.1"
+  files:
+    - path: "pyproject.toml"
+      replace: ['version = "{version}"']
+      format: pep440
+    - path: "pom.xml"
+      replace: ["<version>{version}</version>"]
+      format: maven
+`
+	if err := os.WriteFile(filePath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write to YAML config file: %v", err)
+	}
+
+	vb, err := NewVersionBump(config.Options{ConfigPath: filePath, BumpPart: "pre-major"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.0-alpha.2", vb.GetNewVersion())
+
+	expected := [][]string{{"1.2.0a1", "1.2.0a2"}, {"1.2.0-alpha-1", "1.2.0-alpha-2"}, {"1.2.0-alpha.1", "1.2.0-alpha.2"}}
+	for i, file := range vb.Config.Files {
+		oldVersion, newVersion, err := vb.fileVersions(file)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[i], []string{oldVersion, newVersion})
+	}
+}
+
+// runGit runs a git command in dir, failing the test if it fails, and returns its trimmed output.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com",
+		"-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newGitRepo initializes a git repository in dir and commits the configuration file (versionbump.yaml) in it.
+func newGitRepo(t *testing.T, dir string) {
+	t.Helper()
+	runGit(t, dir, "init", "--initial-branch=main")
+	runGit(t, dir, "add", "versionbump.yaml")
+	runGit(t, dir, "commit", "-m", "Initial commit")
+}
@@ -7,9 +7,13 @@ import (
 	"strings"
 )
 
+// BuildVersion is the build metadata of a version. It is either a label and a build number that is incremented by
+// the pre-build strategy (e.g. `build.3`), or arbitrary dot-separated identifiers (e.g. `sha.1a2b3c4.20241016`).
 type BuildVersion struct {
 	number int
 	label  string
+	// identifiers holds the build metadata, when it isn't a label and build number
+	identifiers []string
 }
 
 // newBuild creates a new BuildVersion instance
@@ -20,45 +24,98 @@ func newBuild(label string, index int) *BuildVersion {
 	}
 }
 
-// Number returns the BuildVersion number
+// NewBuildVersion creates a new BuildVersion instance from a build label and a positive build number, e.g. `build.3`.
+// The label is optional and must be alphanumeric.
+func NewBuildVersion(label string, number int) (*BuildVersion, error) {
+	if !utils.IsAllAlphanumeric(label) {
+		return nil, fmt.Errorf("invalid build label, the label must be alphanumeric: %s", label)
+	}
+	if number < 1 {
+		return nil, fmt.Errorf("invalid build number, the number must be positive: %d", number)
+	}
+	return newBuild(label, number), nil
+}
+
+// NewBuildMetadata creates a BuildVersion from build metadata identifiers, e.g. "sha", "1a2b3c4". Identifiers must
+// be non-empty and contain only ASCII alphanumerics and hyphens.
+func NewBuildMetadata(identifiers ...string) (*BuildVersion, error) {
+	if len(identifiers) == 0 {
+		return nil, fmt.Errorf("invalid build metadata, at least one identifier is required")
+	}
+	for _, identifier := range identifiers {
+		if !ValidateBuildIdentifier(identifier) {
+			return nil, fmt.Errorf("invalid build metadata identifier, identifiers must be alphanumeric or '-': '%s'", identifier)
+		}
+	}
+	return &BuildVersion{identifiers: append([]string{}, identifiers...)}, nil
+}
+
+// Number returns the BuildVersion number, or 0 if the build metadata doesn't have a build number or is nil
 func (b *BuildVersion) Number() int {
+	if b == nil {
+		return 0
+	}
 	return b.number
 }
 
-// Label returns the BuildVersion label
+// Label returns the BuildVersion label, or an empty string if the build metadata is nil
 func (b *BuildVersion) Label() string {
+	if b == nil {
+		return ""
+	}
 	return b.label
 }
 
+// Identifiers returns the dot-separated identifiers of the build metadata
+func (b *BuildVersion) Identifiers() []string {
+	if b != nil && b.identifiers != nil {
+		return append([]string{}, b.identifiers...)
+	}
+	if s := b.String(); s != "" {
+		return strings.Split(s, ".")
+	}
+	return nil
+}
+
+// isCounter returns true if the build metadata is a label and build number that can be incremented
+func (b *BuildVersion) isCounter() bool {
+	return b.identifiers == nil
+}
+
 // parseBuild parses a BuildVersion rootVersion string and returns a new BuildVersion instance
 func parseBuild(buildStr string) (*BuildVersion, error) {
 	if buildStr == "" {
 		return nil, nil
 	}
-	// BuildVersion specific logic
-	// BuildVersion.1
 	vals := strings.Split(buildStr, ".")
-	if len(vals) != 2 {
-		return nil, fmt.Errorf("invalid build version: %s", buildStr)
-	}
-	if vals[1] == "" {
-		return nil, fmt.Errorf("invalid build version, build number is required: %s", buildStr)
+	for _, val := range vals {
+		if !ValidateBuildIdentifier(val) {
+			return nil, fmt.Errorf("invalid build version, identifiers must be alphanumeric or '-': %s", buildStr)
+		}
 	}
-	if !utils.IsAllAlphanumeric(vals[0]) {
-		return nil, fmt.Errorf("invalid build version, build label must be alphanumeric: %s", buildStr)
+
+	// a label and build number (e.g. build.1), or only a build number (e.g. 1)
+	label, number := "", vals[len(vals)-1]
+	if len(vals) == 2 {
+		label = vals[0]
 	}
-	buildNum, err := strconv.Atoi(vals[1])
-	if err != nil {
-		return nil, fmt.Errorf("invalid build version, build number must be an integer: %s", buildStr)
+	if len(vals) <= 2 && utils.IsAllAlphanumeric(label) {
+		// only canonical numbers, so the build version is printed as it was parsed
+		if n, err := strconv.Atoi(number); err == nil && n > 0 && strconv.Itoa(n) == number {
+			return newBuild(label, n), nil
+		}
 	}
-	return &BuildVersion{
-		number: buildNum,
-		label:  vals[0],
-	}, nil
+	return &BuildVersion{identifiers: vals}, nil
 }
 
-// String returns the BuildVersion version string
+// String returns the BuildVersion version string, or an empty string if the build metadata is nil
 func (b *BuildVersion) String() string {
+	if b == nil {
+		return ""
+	}
+	if b.identifiers != nil {
+		return strings.Join(b.identifiers, ".")
+	}
 	if b.number > 0 {
 		if b.label == "" {
 			return fmt.Sprintf("%d", b.number)
@@ -69,9 +126,14 @@ func (b *BuildVersion) String() string {
 	}
 }
 
-// Compare compares two BuildVersion instances.
+// Compare compares two BuildVersion instances. Build metadata that isn't a label and build number is compared
+// lexically.
 // Returns -1 if buildVersion is less than other, 1 if buildVersion is greater than other, and 0 if they are equal.
 func (b *BuildVersion) Compare(other *BuildVersion) int {
+	if !b.isCounter() || !other.isCounter() {
+		return strings.Compare(b.String(), other.String())
+	}
+
 	if b.label != other.label {
 		if b.label < other.label {
 			return -1
@@ -92,3 +154,17 @@ func (b *BuildVersion) Compare(other *BuildVersion) int {
 func (b *BuildVersion) bump() *BuildVersion {
 	return newBuild(b.label, b.number+1)
 }
+
+// ValidateBuildIdentifier checks if the provided string is a valid build metadata identifier, i.e. non-empty and
+// only ASCII alphanumerics and hyphens
+func ValidateBuildIdentifier(identifier string) bool {
+	if identifier == "" {
+		return false
+	}
+	for _, char := range identifier {
+		if char != '-' && !utils.IsAllAlphanumeric(string(char)) {
+			return false
+		}
+	}
+	return true
+}
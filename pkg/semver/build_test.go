@@ -23,3 +23,51 @@ func TestParseBuild(t *testing.T) {
 		}
 	}
 }
+
+func TestParseBuildMetadata(t *testing.T) {
+	tests := []struct {
+		input   string
+		counter bool
+	}{
+		{"build.1", true},
+		{"7", true},
+		{"sha.1a2b3c4.20241016", false},
+		{"sha.0123456", false},
+		{"build.0", false},
+		{"ci-42.dirty", false},
+	}
+	for _, test := range tests {
+		build, err := parseBuild(test.input)
+		if err != nil {
+			t.Fatalf("Unexpected error for input %s: %v", test.input, err)
+		}
+		if result := build.String(); result != test.input {
+			t.Errorf("Expected build version %s, but got %s", test.input, result)
+		}
+		if build.isCounter() != test.counter {
+			t.Errorf("Expected build version %s to be a counter: %v", test.input, test.counter)
+		}
+	}
+
+	for _, input := range []string{"sha..1", "sha_1", "build.1+2"} {
+		if _, err := parseBuild(input); err == nil {
+			t.Errorf("Expected an error for input %s", input)
+		}
+	}
+}
+
+func TestNewBuildMetadata(t *testing.T) {
+	build, err := NewBuildMetadata("sha", "1a2b3c4", "20241016")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result := build.String(); result != "sha.1a2b3c4.20241016" {
+		t.Errorf("Expected build metadata sha.1a2b3c4.20241016, but got %s", result)
+	}
+	if _, err := NewBuildMetadata("sha", ""); err == nil {
+		t.Errorf("Expected an error for an empty identifier")
+	}
+	if _, err := NewBuildMetadata("feature/x"); err == nil {
+		t.Errorf("Expected an error for an invalid identifier")
+	}
+}
@@ -0,0 +1,116 @@
+package semver
+
+import "fmt"
+
+// NewSemanticVersion creates a new SemanticVersion instance from its parts. The pre-release and build versions are
+// optional, and four-part versions can't have either.
+//
+// SemanticVersion instances are immutable, the With* methods return modified copies:
+//
+//	v, _ := semver.NewSemanticVersion(root, nil, nil)
+//	minor, err := v.WithMinor(3)               // 1.3.0
+//	rc, err := minor.WithPreRelease("rc", "1") // 1.3.0-rc.1
+func NewSemanticVersion(version *Version, preRelease *PreReleaseVersion, build *BuildVersion) (*SemanticVersion, error) {
+	if version == nil {
+		return nil, fmt.Errorf("invalid semantic version, the version is required")
+	}
+	if version.fourPart {
+		if preRelease.String() != "" || build.String() != "" {
+			return nil, fmt.Errorf("four-part versions don't have pre-release or build versions: %s", version)
+		}
+		return &SemanticVersion{rootVersion: version}, nil
+	}
+	if preRelease == nil {
+		preRelease = newPrereleaseVersion("", 0, 0, 0)
+	}
+	if build.String() == "" {
+		build = nil
+	}
+	return &SemanticVersion{rootVersion: version, preReleaseVersion: preRelease, buildVersion: build}, nil
+}
+
+// withRoot returns a copy of the SemanticVersion with the given root version. Version numbers must not be negative.
+func (v *SemanticVersion) withRoot(major int, minor int, patch int, revision int) (*SemanticVersion, error) {
+	var root *Version
+	var err error
+	if v.rootVersion.fourPart {
+		root, err = NewFourPartVersion(major, minor, patch, revision)
+	} else {
+		root, err = NewVersion(major, minor, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewSemanticVersion(root, v.preReleaseVersion, v.buildVersion)
+}
+
+// WithMajor returns a copy of the SemanticVersion with the given major version. Unlike Bump, the other parts are
+// unchanged.
+func (v *SemanticVersion) WithMajor(major int) (*SemanticVersion, error) {
+	if v.IsZero() {
+		return nil, errZeroVersion
+	}
+	return v.withRoot(major, v.rootVersion.minor, v.rootVersion.patch, v.rootVersion.revision)
+}
+
+// WithMinor returns a copy of the SemanticVersion with the given minor version. Unlike Bump, the other parts are
+// unchanged.
+func (v *SemanticVersion) WithMinor(minor int) (*SemanticVersion, error) {
+	if v.IsZero() {
+		return nil, errZeroVersion
+	}
+	return v.withRoot(v.rootVersion.major, minor, v.rootVersion.patch, v.rootVersion.revision)
+}
+
+// WithPatch returns a copy of the SemanticVersion with the given patch version. Unlike Bump, the other parts are
+// unchanged.
+func (v *SemanticVersion) WithPatch(patch int) (*SemanticVersion, error) {
+	if v.IsZero() {
+		return nil, errZeroVersion
+	}
+	return v.withRoot(v.rootVersion.major, v.rootVersion.minor, patch, v.rootVersion.revision)
+}
+
+// WithPreRelease returns a copy of the SemanticVersion with a pre-release version made of the given identifiers (see
+// NewPreReleaseVersion), e.g. WithPreRelease("rc", "1") is 1.2.3-rc.1
+func (v *SemanticVersion) WithPreRelease(identifiers ...string) (*SemanticVersion, error) {
+	preRelease, err := NewPreReleaseVersion(identifiers...)
+	if err != nil {
+		return nil, err
+	}
+	return NewSemanticVersion(v.rootVersion, preRelease, v.buildVersion)
+}
+
+// WithoutPreRelease returns a copy of the SemanticVersion without a pre-release version
+func (v *SemanticVersion) WithoutPreRelease() *SemanticVersion {
+	if v.IsZero() {
+		return &SemanticVersion{}
+	}
+	withoutPreRelease, _ := NewSemanticVersion(v.rootVersion, nil, v.buildVersion)
+	return withoutPreRelease
+}
+
+// WithBuild returns a copy of the SemanticVersion with the given build metadata, or without build metadata if `build`
+// is nil. Four-part versions don't have build metadata.
+func (v *SemanticVersion) WithBuild(build *BuildVersion) (*SemanticVersion, error) {
+	return NewSemanticVersion(v.rootVersion, v.preReleaseVersion, build)
+}
+
+// WithBuildMetadata returns a copy of the SemanticVersion with build metadata made of the given identifiers (see
+// NewBuildMetadata), e.g. WithBuildMetadata("sha", "1a2b3c4") is 1.2.3+sha.1a2b3c4
+func (v *SemanticVersion) WithBuildMetadata(identifiers ...string) (*SemanticVersion, error) {
+	build, err := NewBuildMetadata(identifiers...)
+	if err != nil {
+		return nil, err
+	}
+	return NewSemanticVersion(v.rootVersion, v.preReleaseVersion, build)
+}
+
+// WithoutBuild returns a copy of the SemanticVersion without build metadata
+func (v *SemanticVersion) WithoutBuild() *SemanticVersion {
+	if v.IsZero() {
+		return &SemanticVersion{}
+	}
+	withoutBuild, _ := NewSemanticVersion(v.rootVersion, v.preReleaseVersion, nil)
+	return withoutBuild
+}
@@ -0,0 +1,149 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSemanticVersion(t *testing.T) {
+	root, err := NewVersion(1, 2, 3)
+	assert.NoError(t, err)
+	preRelease, err := NewPreReleaseVersion("rc", "1")
+	assert.NoError(t, err)
+	build, err := NewBuildVersion("build", 5)
+	assert.NoError(t, err)
+
+	v, err := NewSemanticVersion(root, preRelease, build)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3-rc.1+build.5", v.String())
+	assert.Equal(t, 0, v.Compare(MustParse("1.2.3-rc.1+build.5")))
+
+	// a version without a pre-release version can still be bumped to one
+	v, err = NewSemanticVersion(root, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", v.String())
+	bumped, err := v.Bump(PreReleaseNewMinor, []string{"alpha"}, "build")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.0-alpha", bumped.String())
+
+	fourPart, err := NewFourPartVersion(1, 2, 3, 4)
+	assert.NoError(t, err)
+	v, err = NewSemanticVersion(fourPart, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", v.String())
+	_, err = NewSemanticVersion(fourPart, preRelease, nil)
+	assert.Error(t, err)
+	_, err = NewSemanticVersion(nil, nil, nil)
+	assert.Error(t, err)
+
+	_, err = NewVersion(1, -2, 3)
+	assert.Error(t, err)
+	_, err = NewFourPartVersion(1, 2, 3, -4)
+	assert.Error(t, err)
+	_, err = NewBuildVersion("build", 0)
+	assert.Error(t, err)
+	_, err = NewBuildVersion("build-1", 1)
+	assert.Error(t, err)
+}
+
+func TestNewPreReleaseVersion(t *testing.T) {
+	tests := []struct {
+		identifiers []string
+		expected    string
+	}{
+		{[]string{"alpha"}, "alpha"},
+		{[]string{"rc", "1"}, "rc.1"},
+		{[]string{"beta", "1", "2"}, "beta.1.2"},
+		{[]string{"1"}, "1"},
+	}
+	for _, test := range tests {
+		preRelease, err := NewPreReleaseVersion(test.identifiers...)
+		assert.NoError(t, err, "unexpected error for identifiers %v", test.identifiers)
+		assert.Equal(t, test.expected, preRelease.String())
+		assert.Equal(t, test.identifiers, preRelease.Identifiers())
+	}
+
+	for _, identifiers := range [][]string{{}, {""}, {"rc", "01"}, {"rc.1"}, {"rc_1"}, {"rc", "1", "dev", "2"}} {
+		_, err := NewPreReleaseVersion(identifiers...)
+		assert.Error(t, err, "expected an error for identifiers %v", identifiers)
+	}
+}
+
+func TestWithMethods(t *testing.T) {
+	v := MustParse("1.2.3-beta.2+build.7")
+
+	major, err := v.WithMajor(4)
+	assert.NoError(t, err)
+	assert.Equal(t, "4.2.3-beta.2+build.7", major.String())
+	minor, err := v.WithMinor(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.3-beta.2+build.7", minor.String())
+	patch, err := v.WithPatch(9)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.9-beta.2+build.7", patch.String())
+	assert.Equal(t, "1.2.3+build.7", v.WithoutPreRelease().String())
+	assert.Equal(t, "1.2.3-beta.2", v.WithoutBuild().String())
+	assert.Equal(t, "1.2.3", v.WithoutPreRelease().WithoutBuild().String())
+
+	minor, err = v.WithMinor(3)
+	assert.NoError(t, err)
+	rc, err := minor.WithPreRelease("rc", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.3-rc.1+build.7", rc.String())
+	withMeta, err := v.WithBuildMetadata("sha", "1a2b3c4")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3-beta.2+sha.1a2b3c4", withMeta.String())
+	build, err := NewBuildVersion("ci", 3)
+	assert.NoError(t, err)
+	withBuild, err := v.WithBuild(build)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3-beta.2+ci.3", withBuild.String())
+
+	_, err = v.WithPreRelease("rc", "01")
+	assert.Error(t, err)
+	_, err = v.WithBuildMetadata("sha!")
+	assert.Error(t, err)
+	_, err = v.WithMajor(-1)
+	assert.Error(t, err)
+
+	// the original version is unchanged
+	assert.Equal(t, "1.2.3-beta.2+build.7", v.String())
+
+	fourPart, _ := ParseFourPartVersion("1.2.3.4")
+	minor, err = fourPart.WithMinor(5)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.3.4", minor.String())
+	_, err = fourPart.WithPatch(-1)
+	assert.Error(t, err)
+	_, err = fourPart.WithPreRelease("rc")
+	assert.Error(t, err)
+	// four-part versions don't have build metadata, so they can always be parsed again
+	_, err = fourPart.WithBuild(build)
+	assert.Error(t, err)
+	_, err = fourPart.WithBuildMetadata("sha", "abc")
+	assert.Error(t, err)
+	assert.Equal(t, "1.2.3.4", fourPart.WithoutBuild().String())
+}
+
+func TestPartAccessors(t *testing.T) {
+	v := MustParse("1.2.3-rc.4+build.5")
+	assert.Equal(t, "rc", v.PreReleaseVersion().Label())
+	assert.Equal(t, 4, v.PreReleaseVersion().Version().Major())
+	assert.Equal(t, []string{"rc", "4"}, v.PreReleaseVersion().Identifiers())
+	assert.Equal(t, "build", v.BuildVersion().Label())
+	assert.Equal(t, 5, v.BuildVersion().Number())
+	assert.Equal(t, []string{"build", "5"}, v.BuildVersion().Identifiers())
+
+	// the parts of a version without a pre-release or build version are empty
+	v = MustParse("1.2.3")
+	assert.Equal(t, "", v.BuildVersion().Label())
+	assert.Equal(t, 0, v.BuildVersion().Number())
+	assert.Nil(t, v.BuildVersion().Identifiers())
+	assert.Equal(t, "", v.BuildVersion().String())
+	assert.Nil(t, v.PreReleaseVersion().Identifiers())
+
+	fourPart, _ := ParseFourPartVersion("1.2.3.4")
+	assert.Equal(t, "", fourPart.PreReleaseVersion().Label())
+	assert.Nil(t, fourPart.PreReleaseVersion().Version())
+}
@@ -0,0 +1,224 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the version syntax of a package ecosystem.
+type Dialect string
+
+const (
+	// DialectSemVer is the version as is
+	DialectSemVer Dialect = "semver"
+	// DialectPEP440 is the Python version syntax, e.g. 1.2.0a1, 1.2.0rc2 and 1.2.0.dev3
+	DialectPEP440 Dialect = "pep440"
+	// DialectNpm is the npm version syntax, which is Semantic Versioning 2.0.0
+	DialectNpm Dialect = "npm"
+	// DialectMaven is the Maven version syntax, e.g. 1.2.0-beta-1 and 1.2.0-SNAPSHOT
+	DialectMaven Dialect = "maven"
+	// DialectNuGet is the NuGet version syntax, which supports four-part versions (e.g. 1.2.3.4)
+	DialectNuGet Dialect = "nuget"
+)
+
+// Dialects are the supported version dialects.
+var Dialects = []Dialect{DialectSemVer, DialectPEP440, DialectNpm, DialectMaven, DialectNuGet}
+
+// pep440Labels map pre-release labels to their PEP 440 equivalent. `dev` is a development release (e.g. 1.2.0.dev3)
+// rather than a pre-release.
+var pep440Labels = map[string]string{
+	"alpha":   "a",
+	"a":       "a",
+	"beta":    "b",
+	"b":       "b",
+	"rc":      "rc",
+	"c":       "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// pep440Pattern matches the normalized form of the PEP 440 versions that have a semantic version equivalent.
+var pep440Pattern = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)(?:(a|b|rc)(\d+))?(?:\.dev(\d+))?(?:\+([a-z0-9]+(?:\.[a-z0-9]+)*))?$`)
+
+// ToDialect formats the version in the syntax of the given Dialect. Versions that can't be expressed in the dialect
+// (e.g. a pre-release label without a PEP 440 equivalent) return an error.
+func (v *SemanticVersion) ToDialect(dialect Dialect) (string, error) {
+	if v.IsZero() {
+		return "", errZeroVersion
+	}
+	switch dialect {
+	case DialectSemVer, "":
+		return v.String(), nil
+	case DialectNpm:
+		if v.rootVersion.fourPart {
+			return "", fmt.Errorf("npm versions don't have four parts: %s", v)
+		}
+		return v.String(), nil
+	case DialectNuGet:
+		// NuGet normalizes a zero revision away
+		if v.rootVersion.fourPart && v.rootVersion.revision == 0 {
+			return newVersion(v.rootVersion.major, v.rootVersion.minor, v.rootVersion.patch).String(), nil
+		}
+		return v.String(), nil
+	case DialectPEP440:
+		return v.toPEP440()
+	case DialectMaven:
+		return v.toMaven()
+	default:
+		return "", fmt.Errorf("invalid version dialect: %s", dialect)
+	}
+}
+
+// ParseDialect parses a version in the syntax of the given Dialect, and returns the equivalent SemanticVersion. It's
+// the inverse of ToDialect.
+func ParseDialect(versionStr string, dialect Dialect) (*SemanticVersion, error) {
+	switch dialect {
+	case DialectSemVer, "":
+		return ParseSemVersion(versionStr)
+	case DialectNpm:
+		// npm accepts a leading `v` or `=`
+		return ParseSemVersion(strings.TrimLeft(strings.TrimSpace(versionStr), "v="))
+	case DialectNuGet:
+		root, _, _ := strings.Cut(versionStr, "-")
+		root, _, _ = strings.Cut(root, "+")
+		if strings.Count(root, ".") == 3 {
+			return ParseFourPartVersion(versionStr)
+		}
+		return ParseSemVersion(versionStr)
+	case DialectPEP440:
+		return parsePEP440(versionStr)
+	case DialectMaven:
+		return parseMaven(versionStr)
+	default:
+		return nil, fmt.Errorf("invalid version dialect: %s", dialect)
+	}
+}
+
+// toPEP440 formats the version as a PEP 440 version, e.g. 1.2.0-alpha.1 -> 1.2.0a1 and 1.2.0-dev.3 -> 1.2.0.dev3.
+// Build metadata becomes a local version label (e.g. +sha.1a2b3c4).
+func (v *SemanticVersion) toPEP440() (string, error) {
+	version := v.rootVersion.String()
+	if v.IsPreRelease() {
+		pr := v.preReleaseVersion
+		if pr.version.minor != 0 || pr.version.patch != 0 {
+			return "", fmt.Errorf("PEP 440 pre-releases have a single number: %s", v)
+		}
+		if pr.label == "dev" {
+			version += fmt.Sprintf(".dev%d", pr.version.major)
+		} else if label, ok := pep440Labels[pr.label]; ok {
+			version += fmt.Sprintf("%s%d", label, pr.version.major)
+		} else {
+			return "", fmt.Errorf("pre-release label '%s' has no PEP 440 equivalent (alpha, beta, rc or dev): %s",
+				pr.label, v)
+		}
+	}
+	if v.buildVersion != nil && v.buildVersion.String() != "" {
+		version += "+" + strings.ToLower(strings.ReplaceAll(v.buildVersion.String(), "-", "."))
+	}
+	return version, nil
+}
+
+// parsePEP440 parses a PEP 440 version, see toPEP440.
+func parsePEP440(versionStr string) (*SemanticVersion, error) {
+	m := pep440Pattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(versionStr)))
+	if m == nil {
+		return nil, fmt.Errorf("invalid or unsupported PEP 440 version: %s", versionStr)
+	}
+	release := strings.Split(m[1], ".")
+	if len(release) > 4 {
+		return nil, fmt.Errorf("PEP 440 version has too many release segments: %s", versionStr)
+	}
+	if m[2] != "" && m[4] != "" {
+		return nil, fmt.Errorf("PEP 440 development pre-releases have no semantic version equivalent: %s", versionStr)
+	}
+	for len(release) < 3 {
+		release = append(release, "0")
+	}
+
+	version := strings.Join(release, ".")
+	switch {
+	case m[2] != "":
+		label := map[string]string{"a": "alpha", "b": "beta", "rc": "rc"}[m[2]]
+		version += "-" + preReleaseString(label, m[3])
+	case m[4] != "":
+		version += "-" + preReleaseString("dev", m[4])
+	}
+	if m[5] != "" {
+		version += "+" + m[5]
+	}
+	if len(release) == 4 {
+		if m[2] != "" || m[4] != "" || m[5] != "" {
+			return nil, fmt.Errorf("four-part versions don't have pre-release or build versions: %s", versionStr)
+		}
+		return ParseFourPartVersion(version)
+	}
+	return ParseSemVersion(version)
+}
+
+// preReleaseString returns a pre-release with the given label and number, omitting the number 0 (e.g. alpha.0 ->
+// alpha).
+func preReleaseString(label string, number string) string {
+	if n, err := strconv.Atoi(number); err == nil && n == 0 {
+		return label
+	}
+	return label + "." + strings.TrimLeft(number, "0")
+}
+
+// toMaven formats the version as a Maven version, e.g. 1.2.0-beta.1 -> 1.2.0-beta-1 and 1.2.0-snapshot ->
+// 1.2.0-SNAPSHOT. Maven has no build metadata, so it is dropped.
+func (v *SemanticVersion) toMaven() (string, error) {
+	version := v.rootVersion.String()
+	if v.IsPreRelease() {
+		pr := v.preReleaseVersion
+		if strings.EqualFold(pr.label, "snapshot") {
+			if pr.String() != pr.label {
+				return "", fmt.Errorf("Maven snapshots aren't numbered: %s", v)
+			}
+			return version + "-SNAPSHOT", nil
+		}
+		version += "-" + strings.ReplaceAll(pr.String(), ".", "-")
+	}
+	return version, nil
+}
+
+// parseMaven parses a Maven version, see toMaven.
+func parseMaven(versionStr string) (*SemanticVersion, error) {
+	root, qualifier, found := strings.Cut(strings.TrimSpace(versionStr), "-")
+	if found && qualifier == "" {
+		return nil, fmt.Errorf("invalid or unsupported Maven version: %s", versionStr)
+	}
+	release := strings.Split(root, ".")
+	for len(release) < 3 {
+		release = append(release, "0")
+	}
+	version := strings.Join(release, ".")
+	if len(release) == 4 {
+		if qualifier != "" {
+			return nil, fmt.Errorf("four-part versions don't have pre-release versions: %s", versionStr)
+		}
+		return ParseFourPartVersion(version)
+	}
+	if qualifier == "SNAPSHOT" {
+		qualifier = "snapshot"
+	}
+	if qualifier != "" {
+		version += "-" + strings.ReplaceAll(qualifier, "-", ".")
+	}
+	v, err := ParseSemVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid or unsupported Maven version: %s", versionStr)
+	}
+	return v, nil
+}
+
+// IsDialect returns true if the name is one of the supported Dialects.
+func IsDialect(name string) bool {
+	for _, dialect := range Dialects {
+		if name == string(dialect) {
+			return true
+		}
+	}
+	return false
+}
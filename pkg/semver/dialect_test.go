@@ -0,0 +1,116 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDialect(t *testing.T) {
+	tests := []struct {
+		version  string
+		dialect  Dialect
+		expected string
+	}{
+		{"1.2.0", DialectPEP440, "1.2.0"},
+		{"1.2.0-alpha.1", DialectPEP440, "1.2.0a1"},
+		{"1.2.0-beta", DialectPEP440, "1.2.0b0"},
+		{"1.2.0-rc.2", DialectPEP440, "1.2.0rc2"},
+		{"1.2.0-dev.3", DialectPEP440, "1.2.0.dev3"},
+		{"1.2.0+build-1", DialectPEP440, "1.2.0+build.1"},
+		{"1.2.0-alpha.1", DialectNpm, "1.2.0-alpha.1"},
+		{"1.2.0-snapshot", DialectMaven, "1.2.0-SNAPSHOT"},
+		{"1.2.0-beta.1", DialectMaven, "1.2.0-beta-1"},
+		{"1.2.0+build.1", DialectMaven, "1.2.0"},
+		{"1.2.0-rc.1+build.1", DialectNuGet, "1.2.0-rc.1+build.1"},
+		{"1.2.0-rc.1", DialectSemVer, "1.2.0-rc.1"},
+	}
+	for _, test := range tests {
+		v, err := ParseSemVersion(test.version)
+		assert.NoError(t, err)
+		actual, err := v.ToDialect(test.dialect)
+		assert.NoError(t, err, "unexpected error for %s in %s", test.version, test.dialect)
+		assert.Equal(t, test.expected, actual)
+	}
+
+	for _, input := range []string{"1.2.0-nightly.1", "1.2.0-alpha.1.2"} {
+		v, _ := ParseSemVersion(input)
+		_, err := v.ToDialect(DialectPEP440)
+		assert.Error(t, err, "expected an error for version %s", input)
+	}
+	v, _ := ParseSemVersion("1.2.0-snapshot.1")
+	_, err := v.ToDialect(DialectMaven)
+	assert.Error(t, err)
+	_, err = v.ToDialect("cargo")
+	assert.Error(t, err)
+}
+
+func TestToDialectFourPart(t *testing.T) {
+	v, _ := ParseFourPartVersion("1.2.3.4")
+	nuget, err := v.ToDialect(DialectNuGet)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", nuget)
+	pep440, err := v.ToDialect(DialectPEP440)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", pep440)
+	_, err = v.ToDialect(DialectNpm)
+	assert.Error(t, err)
+
+	// NuGet drops a zero revision
+	v, _ = ParseFourPartVersion("1.2.3.0")
+	nuget, err = v.ToDialect(DialectNuGet)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", nuget)
+}
+
+func TestParseDialect(t *testing.T) {
+	tests := []struct {
+		input    string
+		dialect  Dialect
+		expected string
+	}{
+		{"1.2.0a1", DialectPEP440, "1.2.0-alpha.1"},
+		{"1.2.0b0", DialectPEP440, "1.2.0-beta"},
+		{"1.2.0RC2", DialectPEP440, "1.2.0-rc.2"},
+		{"1.2.0.dev3", DialectPEP440, "1.2.0-dev.3"},
+		{"1.2", DialectPEP440, "1.2.0"},
+		{"1.2.0+build.1", DialectPEP440, "1.2.0+build.1"},
+		{"1.2.3.4", DialectPEP440, "1.2.3.4"},
+		{"v1.2.0-alpha.1", DialectNpm, "1.2.0-alpha.1"},
+		{"=1.2.0", DialectNpm, "1.2.0"},
+		{"1.2.0-SNAPSHOT", DialectMaven, "1.2.0-snapshot"},
+		{"1.2.0-beta-1", DialectMaven, "1.2.0-beta.1"},
+		{"1.2", DialectMaven, "1.2.0"},
+		{"1.2.3.4", DialectNuGet, "1.2.3.4"},
+		{"1.2.3-rc.1", DialectNuGet, "1.2.3-rc.1"},
+	}
+	for _, test := range tests {
+		v, err := ParseDialect(test.input, test.dialect)
+		assert.NoError(t, err, "unexpected error for %s in %s", test.input, test.dialect)
+		if err == nil {
+			assert.Equal(t, test.expected, v.String())
+		}
+	}
+
+	for _, input := range []string{"1.2.0a1.dev2", "1.2.0.post1", "1.2.3.4.5", "1.2.0-beta"} {
+		_, err := ParseDialect(input, DialectPEP440)
+		assert.Error(t, err, "expected an error for version %s", input)
+	}
+	_, err := ParseDialect("1.2.0-", DialectMaven)
+	assert.Error(t, err)
+}
+
+func TestDialectRoundTrip(t *testing.T) {
+	for _, dialect := range Dialects {
+		for _, input := range []string{"1.2.0", "1.2.0-alpha.1", "1.2.0-beta.2", "1.2.0-rc.3"} {
+			v, _ := ParseSemVersion(input)
+			formatted, err := v.ToDialect(dialect)
+			assert.NoError(t, err)
+			parsed, err := ParseDialect(formatted, dialect)
+			assert.NoError(t, err, "unexpected error parsing %s in %s", formatted, dialect)
+			if err == nil {
+				assert.Equal(t, input, parsed.String(), "round trip of %s in %s", input, dialect)
+			}
+		}
+	}
+}
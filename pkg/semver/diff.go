@@ -0,0 +1,165 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Component is a part of a version that differs between two versions.
+type Component string
+
+const (
+	ComponentNone       Component = "none"
+	ComponentMajor      Component = "major"
+	ComponentMinor      Component = "minor"
+	ComponentPatch      Component = "patch"
+	ComponentRevision   Component = "revision"
+	ComponentPreRelease Component = "prerelease"
+	ComponentBuild      Component = "build"
+)
+
+// Direction is the direction of the change between two versions.
+type Direction string
+
+const (
+	DirectionNone Direction = "none"
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+// Difference classifies the change from one version to another.
+type Difference struct {
+	From *SemanticVersion
+	To   *SemanticVersion
+	// Component is the most significant component that changed
+	Component Component
+	Direction Direction
+	// CrossesMajorZero is true if the change moves between the initial development versions (0.y.z) and the stable
+	// versions (1.0.0 and later)
+	CrossesMajorZero bool
+	// CrossesPreRelease is true if the change moves between a pre-release version and a release version
+	CrossesPreRelease bool
+}
+
+// Diff classifies the change from version `a` to version `b`, ordering pre-release labels lexically.
+func Diff(a *SemanticVersion, b *SemanticVersion) Difference {
+	return DiffWithLabels(a, b, nil)
+}
+
+// DiffWithLabels classifies the change from version `a` to version `b`, ordering pre-release labels by their position
+// in `labels` (see CompareWithLabels). As in CompareWithLabels, the zero value precedes every version, so a change
+// from or to the zero value is a major change that crosses no stability boundary.
+func DiffWithLabels(a *SemanticVersion, b *SemanticVersion, labels []string) Difference {
+	d := Difference{
+		From:      a,
+		To:        b,
+		Component: diffComponent(a, b),
+	}
+	if !a.IsZero() && !b.IsZero() {
+		d.CrossesMajorZero = (a.rootVersion.major == 0) != (b.rootVersion.major == 0)
+		d.CrossesPreRelease = a.IsPreRelease() != b.IsPreRelease()
+	}
+	cmp := a.CompareWithLabels(b, labels)
+	if cmp == 0 && d.Component == ComponentBuild {
+		cmp = a.buildVersion.Compare(b.buildVersion)
+	}
+	switch {
+	case cmp < 0:
+		d.Direction = DirectionUp
+	case cmp > 0:
+		d.Direction = DirectionDown
+	default:
+		d.Direction = DirectionNone
+	}
+	return d
+}
+
+// diffComponent returns the most significant component that differs between two versions
+func diffComponent(a *SemanticVersion, b *SemanticVersion) Component {
+	switch {
+	case a.IsZero() && b.IsZero():
+		return ComponentNone
+	case a.IsZero() || b.IsZero():
+		return ComponentMajor
+	case a.rootVersion.major != b.rootVersion.major:
+		return ComponentMajor
+	case a.rootVersion.minor != b.rootVersion.minor:
+		return ComponentMinor
+	case a.rootVersion.patch != b.rootVersion.patch:
+		return ComponentPatch
+	case a.rootVersion.revision != b.rootVersion.revision:
+		return ComponentRevision
+	case a.preReleaseVersion.String() != b.preReleaseVersion.String():
+		return ComponentPreRelease
+	case a.buildVersion.String() != b.buildVersion.String():
+		return ComponentBuild
+	default:
+		return ComponentNone
+	}
+}
+
+// CrossesStability returns true if the change crosses a stability boundary: from 0.y.z to 1.0.0 or later (or back),
+// or between a pre-release and a release version
+func (d Difference) CrossesStability() bool {
+	return d.CrossesMajorZero || d.CrossesPreRelease
+}
+
+// SkipsVersions returns true if an increase skips versions, e.g. 1.2.3 -> 1.4.0 skips 1.3.0, and 1.2.3 -> 1.3.1
+// skips 1.3.0. Only the major, minor and patch versions are considered.
+func (d Difference) SkipsVersions() bool {
+	if d.Direction != DirectionUp || d.From.IsZero() || d.To.IsZero() {
+		return false
+	}
+	from, to := d.From.rootVersion, d.To.rootVersion
+	switch d.Component {
+	case ComponentMajor:
+		return to.major > from.major+1 || to.minor != 0 || to.patch != 0
+	case ComponentMinor:
+		return to.minor > from.minor+1 || to.patch != 0
+	case ComponentPatch:
+		return to.patch > from.patch+1
+	default:
+		return false
+	}
+}
+
+// StabilityChanges describes the stability boundaries crossed by the change, e.g. "0.x -> 1.x" and
+// "pre-release -> release"
+func (d Difference) StabilityChanges() []string {
+	if !d.CrossesStability() {
+		return nil
+	}
+	stability := func(isZero bool, isPreRelease bool) (string, string) {
+		major, release := "1.x", "release"
+		if isZero {
+			major = "0.x"
+		}
+		if isPreRelease {
+			release = "pre-release"
+		}
+		return major, release
+	}
+	fromMajor, fromRelease := stability(d.From.rootVersion.major == 0, d.From.IsPreRelease())
+	toMajor, toRelease := stability(d.To.rootVersion.major == 0, d.To.IsPreRelease())
+
+	var changes []string
+	if d.CrossesMajorZero {
+		changes = append(changes, fmt.Sprintf("%s -> %s", fromMajor, toMajor))
+	}
+	if d.CrossesPreRelease {
+		changes = append(changes, fmt.Sprintf("%s -> %s", fromRelease, toRelease))
+	}
+	return changes
+}
+
+// String returns a summary of the change, e.g. "minor up (1.2.3 -> 1.3.0)"
+func (d Difference) String() string {
+	summary := fmt.Sprintf("%s %s (%s -> %s)", d.Component, d.Direction, d.From, d.To)
+	if d.Component == ComponentNone {
+		summary = fmt.Sprintf("no change (%s)", d.From)
+	}
+	if changes := d.StabilityChanges(); len(changes) > 0 {
+		summary += ", crosses " + strings.Join(changes, ", ")
+	}
+	return summary
+}
@@ -0,0 +1,103 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		from              string
+		to                string
+		component         Component
+		direction         Direction
+		crossesMajorZero  bool
+		crossesPreRelease bool
+	}{
+		{"1.2.3", "2.0.0", ComponentMajor, DirectionUp, false, false},
+		{"1.2.3", "1.3.0", ComponentMinor, DirectionUp, false, false},
+		{"1.2.3", "1.2.4", ComponentPatch, DirectionUp, false, false},
+		{"1.2.3", "1.2.2", ComponentPatch, DirectionDown, false, false},
+		{"0.9.1", "1.0.0", ComponentMajor, DirectionUp, true, false},
+		{"1.0.0", "0.9.0", ComponentMajor, DirectionDown, true, false},
+		{"1.2.0-rc.1", "1.2.0", ComponentPreRelease, DirectionUp, false, true},
+		{"1.2.0", "1.3.0-alpha", ComponentMinor, DirectionUp, false, true},
+		{"1.2.0-beta", "1.2.0-alpha", ComponentPreRelease, DirectionDown, false, false},
+		{"1.2.0+build.1", "1.2.0+build.2", ComponentBuild, DirectionUp, false, false},
+		{"1.2.0", "1.2.0", ComponentNone, DirectionNone, false, false},
+	}
+	for _, test := range tests {
+		d := Diff(MustParse(test.from), MustParse(test.to))
+		assert.Equal(t, test.component, d.Component, "component of %s -> %s", test.from, test.to)
+		assert.Equal(t, test.direction, d.Direction, "direction of %s -> %s", test.from, test.to)
+		assert.Equal(t, test.crossesMajorZero, d.CrossesMajorZero, "0.x boundary of %s -> %s", test.from, test.to)
+		assert.Equal(t, test.crossesPreRelease, d.CrossesPreRelease, "pre-release boundary of %s -> %s", test.from, test.to)
+		assert.Equal(t, test.crossesMajorZero || test.crossesPreRelease, d.CrossesStability())
+	}
+
+	// pre-release labels are ordered as configured
+	d := DiffWithLabels(MustParse("1.0.0-preview"), MustParse("1.0.0-rc"), []string{"preview", "rc"})
+	assert.Equal(t, DirectionUp, d.Direction)
+	d = DiffWithLabels(MustParse("1.0.0-rc"), MustParse("1.0.0-dev"), []string{"dev", "preview", "rc"})
+	assert.Equal(t, DirectionDown, d.Direction)
+
+	from, _ := ParseFourPartVersion("1.2.3.4")
+	to, _ := ParseFourPartVersion("1.2.3.5")
+	d = Diff(from, to)
+	assert.Equal(t, ComponentRevision, d.Component)
+	assert.Equal(t, DirectionUp, d.Direction)
+}
+
+func TestDifferenceSummary(t *testing.T) {
+	d := Diff(MustParse("0.9.1"), MustParse("1.0.0-rc.1"))
+	assert.Equal(t, []string{"0.x -> 1.x", "release -> pre-release"}, d.StabilityChanges())
+	assert.Equal(t, "major up (0.9.1 -> 1.0.0-rc.1), crosses 0.x -> 1.x, release -> pre-release", d.String())
+	assert.Equal(t, "no change (1.2.3)", Diff(MustParse("1.2.3"), MustParse("1.2.3")).String())
+
+	tests := []struct {
+		from  string
+		to    string
+		skips bool
+	}{
+		{"1.2.3", "2.0.0", false},
+		{"1.2.3", "3.0.0", true},
+		{"1.2.3", "2.1.0", true},
+		{"1.2.3", "1.4.0", true},
+		{"1.2.3", "1.3.1", true},
+		{"1.2.3", "1.2.5", true},
+		{"1.2.3-rc.1", "1.2.3", false},
+		{"1.2.3", "1.2.1", false},
+	}
+	for _, test := range tests {
+		d := Diff(MustParse(test.from), MustParse(test.to))
+		assert.Equal(t, test.skips, d.SkipsVersions(), "%s -> %s", test.from, test.to)
+	}
+}
+
+func TestDiffZeroValue(t *testing.T) {
+	zero := &SemanticVersion{}
+	d := Diff(zero, zero)
+	assert.Equal(t, ComponentNone, d.Component)
+	assert.Equal(t, DirectionNone, d.Direction)
+	assert.Empty(t, d.StabilityChanges())
+	assert.False(t, d.SkipsVersions())
+
+	d = Diff(nil, nil)
+	assert.Equal(t, ComponentNone, d.Component)
+	assert.Equal(t, DirectionNone, d.Direction)
+
+	// the zero value precedes every version
+	d = Diff(zero, MustParse("0.1.0-rc.1"))
+	assert.Equal(t, ComponentMajor, d.Component)
+	assert.Equal(t, DirectionUp, d.Direction)
+	assert.False(t, d.CrossesStability())
+	assert.Empty(t, d.StabilityChanges())
+	assert.False(t, d.SkipsVersions())
+	assert.Equal(t, "major up ( -> 0.1.0-rc.1)", d.String())
+
+	d = Diff(MustParse("1.2.3"), nil)
+	assert.Equal(t, ComponentMajor, d.Component)
+	assert.Equal(t, DirectionDown, d.Direction)
+	assert.False(t, d.CrossesStability())
+}
@@ -0,0 +1,113 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// MustParse parses a semantic version string like ParseSemVersion, and panics if it is invalid. It simplifies the
+// initialization of variables with constant versions.
+func MustParse(versionStr string) *SemanticVersion {
+	v, err := ParseSemVersion(versionStr)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// IsZero returns true if the SemanticVersion is nil or the zero value, e.g. an unset struct field. The zero value
+// sorts before every other version, and the methods deriving new versions from it return errZeroVersion.
+func (v *SemanticVersion) IsZero() bool {
+	return v == nil || v.rootVersion == nil
+}
+
+// errZeroVersion is returned for operations on the zero value
+var errZeroVersion = fmt.Errorf("invalid semantic version, the version is empty")
+
+// parseText parses a semantic version, or a four-part version so that every version String returns can be parsed
+func parseText(versionStr string) (*SemanticVersion, error) {
+	v, err := ParseSemVersion(versionStr)
+	if err != nil {
+		if fourPart, fourPartErr := ParseFourPartVersion(versionStr); fourPartErr == nil {
+			return fourPart, nil
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The zero value is an empty string.
+func (v SemanticVersion) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty string is the zero value.
+func (v *SemanticVersion) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = SemanticVersion{}
+		return nil
+	}
+	parsed, err := parseText(string(text))
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the version is a JSON string
+func (v SemanticVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The version must be a JSON string, and `null` leaves it unchanged.
+func (v *SemanticVersion) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("version must be a JSON string: %s", data)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v2 and gopkg.in/yaml.v3, the version is a
+// YAML string
+func (v SemanticVersion) MarshalYAML() (interface{}, error) {
+	return v.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2, which gopkg.in/yaml.v3 supports as
+// well
+func (v *SemanticVersion) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Scan implements sql.Scanner for string and []byte columns. NULL is the zero value.
+func (v *SemanticVersion) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*v = SemanticVersion{}
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(src))
+	case []byte:
+		return v.UnmarshalText(src)
+	default:
+		return fmt.Errorf("cannot scan %T into a semantic version", src)
+	}
+}
+
+// Value implements driver.Valuer, the version is stored as a string and the zero value as NULL
+func (v SemanticVersion) Value() (driver.Value, error) {
+	if v.IsZero() {
+		return nil, nil
+	}
+	return v.String(), nil
+}
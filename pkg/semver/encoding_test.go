@@ -0,0 +1,138 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yamlv2 "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type release struct {
+	Version  SemanticVersion  `json:"version" yaml:"version"`
+	Previous *SemanticVersion `json:"previous,omitempty" yaml:"previous,omitempty"`
+}
+
+func TestMarshalText(t *testing.T) {
+	v := MustParse("1.2.3-rc.1+build.5")
+	text, err := v.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3-rc.1+build.5", string(text))
+
+	var parsed SemanticVersion
+	assert.NoError(t, parsed.UnmarshalText(text))
+	assert.Equal(t, 0, parsed.Compare(v))
+
+	// four-part versions round trip as well
+	fourPart, _ := ParseFourPartVersion("1.2.3.4")
+	text, _ = fourPart.MarshalText()
+	assert.NoError(t, parsed.UnmarshalText(text))
+	assert.Equal(t, "1.2.3.4", parsed.String())
+
+	assert.Error(t, parsed.UnmarshalText([]byte("01.2.3")))
+	assert.NoError(t, parsed.UnmarshalText(nil))
+	assert.True(t, parsed.IsZero())
+	assert.Equal(t, "", parsed.String())
+}
+
+func TestMarshalJSON(t *testing.T) {
+	r := release{Version: *MustParse("1.2.3"), Previous: MustParse("1.2.2-beta.1")}
+	data, err := json.Marshal(r)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version":"1.2.3","previous":"1.2.2-beta.1"}`, string(data))
+
+	var decoded release
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "1.2.3", decoded.Version.String())
+	assert.Equal(t, "1.2.2-beta.1", decoded.Previous.String())
+
+	decoded = release{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"version":"2.0.0","previous":null}`), &decoded))
+	assert.Equal(t, "2.0.0", decoded.Version.String())
+	assert.Nil(t, decoded.Previous)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"version":"v2.0"}`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`{"version":200}`), &decoded))
+}
+
+func TestMarshalYAML(t *testing.T) {
+	r := release{Version: *MustParse("1.2.3"), Previous: MustParse("1.2.2-beta.1")}
+	expected := "version: 1.2.3\nprevious: 1.2.2-beta.1\n"
+
+	data, err := yamlv2.Marshal(r)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+	var decoded release
+	assert.NoError(t, yamlv2.Unmarshal(data, &decoded))
+	assert.Equal(t, "1.2.3", decoded.Version.String())
+	assert.Equal(t, "1.2.2-beta.1", decoded.Previous.String())
+	assert.Error(t, yamlv2.Unmarshal([]byte("version: 1.2"), &decoded))
+
+	data, err = yamlv3.Marshal(r)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+	decoded = release{}
+	assert.NoError(t, yamlv3.Unmarshal(data, &decoded))
+	assert.Equal(t, "1.2.3", decoded.Version.String())
+	assert.Equal(t, "1.2.2-beta.1", decoded.Previous.String())
+	assert.Error(t, yamlv3.Unmarshal([]byte("version: 1.2"), &decoded))
+}
+
+func TestScanValue(t *testing.T) {
+	var v SemanticVersion
+	assert.NoError(t, v.Scan("1.2.3-alpha"))
+	assert.Equal(t, "1.2.3-alpha", v.String())
+	assert.NoError(t, v.Scan([]byte("1.2.4")))
+	assert.Equal(t, "1.2.4", v.String())
+
+	value, err := v.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.4", value)
+
+	assert.NoError(t, v.Scan(nil))
+	assert.True(t, v.IsZero())
+	value, err = v.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	assert.Error(t, v.Scan(42))
+	assert.Error(t, v.Scan("not a version"))
+}
+
+func TestMustParse(t *testing.T) {
+	assert.Equal(t, "1.2.3", MustParse("1.2.3").String())
+	assert.Panics(t, func() { MustParse("v1.2.3") })
+
+	var v *SemanticVersion
+	assert.Equal(t, "", v.String())
+}
+
+func TestZeroValue(t *testing.T) {
+	var zero SemanticVersion
+	assert.NoError(t, zero.UnmarshalText([]byte("")))
+	assert.True(t, zero.IsZero())
+
+	_, err := zero.Bump(Patch, []string{"alpha"}, "build")
+	assert.Error(t, err)
+	_, err = zero.BumpChannel("beta")
+	assert.Error(t, err)
+	_, err = zero.WithMajor(1)
+	assert.Error(t, err)
+	_, err = zero.WithPreRelease("rc")
+	assert.Error(t, err)
+	_, err = zero.ToDialect(DialectPEP440)
+	assert.Error(t, err)
+	_, err = zero.ToSemVer(RevisionAsBuild)
+	assert.Error(t, err)
+
+	// the zero value precedes every version
+	assert.Equal(t, -1, zero.Compare(MustParse("0.0.0")))
+	assert.Equal(t, 1, MustParse("0.0.0").Compare(&zero))
+	assert.Equal(t, 0, zero.Compare(&SemanticVersion{}))
+
+	assert.False(t, zero.IsPreRelease())
+	assert.Equal(t, 0, zero.RootVersion().Major())
+	assert.Equal(t, "", zero.RootVersion().String())
+	assert.True(t, zero.WithoutBuild().IsZero())
+}
@@ -0,0 +1,118 @@
+package semver
+
+import "fmt"
+
+// VersionScheme is the format of the versions of a project.
+type VersionScheme string
+
+const (
+	// SchemeSemVer versions are semantic versions (e.g. 1.2.3-rc.1+build.1)
+	SchemeSemVer VersionScheme = "semver"
+	// SchemeFourPart versions are four-part numeric versions (e.g. 1.2.3.4), as used for .NET assemblies and Windows
+	// resources
+	SchemeFourPart VersionScheme = "four-part"
+)
+
+// RevisionMapping is how the revision of a four-part version is represented in a semantic version, e.g. for NuGet
+// packages.
+type RevisionMapping string
+
+const (
+	// RevisionAsBuild maps the revision to build metadata (1.2.3.4 <-> 1.2.3+4). Build metadata doesn't affect the
+	// precedence of semantic versions, so versions differing only in the revision are equal.
+	RevisionAsBuild RevisionMapping = "build"
+	// RevisionAsPreRelease maps the revision to a numbered pre-release (1.2.3.4 <-> 1.2.3-r.4), so revisions keep
+	// their order (1.2.3-r.9 < 1.2.3-r.10). The revision is a separate identifier, since `r4` would be a single
+	// alphanumeric identifier, which is compared lexically (1.2.3-r10 < 1.2.3-r9). Note the semantic version precedes
+	// the release 1.2.3.
+	RevisionAsPreRelease RevisionMapping = "prerelease"
+)
+
+// ParseFourPartVersion parses a four-part version string (e.g. "1.2.3.4") and returns a new SemanticVersion instance.
+// Four-part versions don't have pre-release or build versions.
+func ParseFourPartVersion(versionStr string) (*SemanticVersion, error) {
+	version, err := parseFourPartVersion(versionStr)
+	if err != nil {
+		return nil, err
+	}
+	return &SemanticVersion{rootVersion: version}, nil
+}
+
+// ParseWithScheme parses a version string of the given VersionScheme. An empty scheme is SchemeSemVer.
+func ParseWithScheme(versionStr string, scheme VersionScheme) (*SemanticVersion, error) {
+	switch scheme {
+	case SchemeSemVer, "":
+		return ParseSemVersion(versionStr)
+	case SchemeFourPart:
+		return ParseFourPartVersion(versionStr)
+	default:
+		return nil, fmt.Errorf("invalid version scheme: %s", scheme)
+	}
+}
+
+// ToSemVer converts a four-part version to a semantic version, mapping the revision as specified. A revision of 0 is
+// omitted (1.2.3.0 -> 1.2.3).
+func (v *SemanticVersion) ToSemVer(mapping RevisionMapping) (*SemanticVersion, error) {
+	if v.IsZero() {
+		return nil, errZeroVersion
+	}
+	if !v.rootVersion.fourPart {
+		return nil, fmt.Errorf("not a four-part version: %s", v)
+	}
+	root := newVersion(v.rootVersion.major, v.rootVersion.minor, v.rootVersion.patch)
+	preRelease := newPrereleaseVersion("", 0, 0, 0)
+	var build *BuildVersion
+	switch mapping {
+	case RevisionAsBuild:
+		if v.rootVersion.revision != 0 {
+			build = newBuild("", v.rootVersion.revision)
+		}
+	case RevisionAsPreRelease:
+		if v.rootVersion.revision != 0 {
+			preRelease = newPrereleaseVersion("r", v.rootVersion.revision, 0, 0)
+		}
+	default:
+		return nil, fmt.Errorf("invalid revision mapping: %s", mapping)
+	}
+	return NewSemanticVersion(root, preRelease, build)
+}
+
+// ToFourPart converts a semantic version to a four-part version, reading the revision as mapped by ToSemVer. A version
+// without a revision has revision 0 (1.2.3 -> 1.2.3.0).
+func (v *SemanticVersion) ToFourPart(mapping RevisionMapping) (*SemanticVersion, error) {
+	if v.IsZero() {
+		return nil, errZeroVersion
+	}
+	if v.rootVersion.fourPart {
+		return v, nil
+	}
+	revision := 0
+	switch mapping {
+	case RevisionAsBuild:
+		if v.IsPreRelease() {
+			return nil, fmt.Errorf("four-part versions don't have pre-release versions: %s", v)
+		}
+		if v.buildVersion != nil && v.buildVersion.String() != "" {
+			if v.buildVersion.label != "" || !v.buildVersion.isCounter() {
+				return nil, fmt.Errorf("build metadata is not a revision: %s", v)
+			}
+			revision = v.buildVersion.number
+		}
+	case RevisionAsPreRelease:
+		if v.buildVersion != nil && v.buildVersion.String() != "" {
+			return nil, fmt.Errorf("four-part versions don't have build versions: %s", v)
+		}
+		if v.IsPreRelease() {
+			preRelease := v.preReleaseVersion
+			if preRelease.label != "r" || preRelease.version.major == 0 || preRelease.version.minor != 0 ||
+				preRelease.version.patch != 0 {
+				return nil, fmt.Errorf("pre-release version is not a revision: %s", v)
+			}
+			revision = preRelease.version.major
+		}
+	default:
+		return nil, fmt.Errorf("invalid revision mapping: %s", mapping)
+	}
+	root := v.rootVersion
+	return &SemanticVersion{rootVersion: newFourPartVersion(root.major, root.minor, root.patch, revision)}, nil
+}
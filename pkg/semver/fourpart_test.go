@@ -0,0 +1,140 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFourPartVersion(t *testing.T) {
+	v, err := ParseFourPartVersion("1.2.3.4")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", v.String())
+	assert.Equal(t, 4, v.RootVersion().Revision())
+	assert.Equal(t, true, v.RootVersion().IsFourPart())
+
+	for _, input := range []string{"1.2.3", "1.2.3.4.5", "1.2.3.x", "1.2.3.-1", "1.2.3.+4", "01.2.3.4", "1.2.3.04", "1.2.3.4-rc.1"} {
+		_, err := ParseFourPartVersion(input)
+		assert.Error(t, err, "expected an error for version %s", input)
+	}
+
+	// semantic versions still have exactly three parts
+	_, err = ParseWithScheme("1.2.3.4", SchemeSemVer)
+	assert.Error(t, err)
+	v, err = ParseWithScheme("1.2.3.4", SchemeFourPart)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", v.String())
+}
+
+func TestBumpFourPart(t *testing.T) {
+	tests := []struct {
+		strategy BumpStrategy
+		expected string
+	}{
+		{Major, "2.0.0.0"},
+		{Minor, "1.3.0.0"},
+		{Patch, "1.2.4.0"},
+		{Revision, "1.2.3.5"},
+		{Release, "1.2.3.4"},
+	}
+	v, _ := ParseFourPartVersion("1.2.3.4")
+	for _, test := range tests {
+		bumped, err := v.Bump(test.strategy, []string{"alpha"}, "build")
+		assert.NoError(t, err, "unexpected error for strategy %s", test.strategy)
+		assert.Equal(t, test.expected, bumped.String())
+	}
+
+	for _, strategy := range []BumpStrategy{PreRelease, PreReleaseBuild, PreReleaseNewMinor} {
+		_, err := v.Bump(strategy, []string{"alpha"}, "build")
+		assert.Error(t, err, "expected an error for strategy %s", strategy)
+	}
+	_, err := v.BumpChannel("beta")
+	assert.Error(t, err)
+
+	semVer, _ := ParseSemVersion("1.2.3")
+	_, err = semVer.Bump(Revision, []string{"alpha"}, "build")
+	assert.Error(t, err, "expected an error for the revision of a semantic version")
+}
+
+func TestSortFourPartVersions(t *testing.T) {
+	versions := make([]*SemanticVersion, 0)
+	for _, s := range []string{"1.2.3.10", "1.2.3.4", "1.10.0.0", "1.2.3.0", "1.2.4.1"} {
+		v, err := ParseFourPartVersion(s)
+		assert.NoError(t, err)
+		versions = append(versions, v)
+	}
+	SortVersions(versions)
+
+	expected := []string{"1.10.0.0", "1.2.4.1", "1.2.3.10", "1.2.3.4", "1.2.3.0"}
+	for i, v := range versions {
+		assert.Equal(t, expected[i], v.String())
+	}
+}
+
+func TestRevisionMapping(t *testing.T) {
+	tests := []struct {
+		fourPart string
+		mapping  RevisionMapping
+		semVer   string
+	}{
+		{"1.2.3.4", RevisionAsBuild, "1.2.3+4"},
+		{"1.2.3.4", RevisionAsPreRelease, "1.2.3-r.4"},
+		{"1.2.3.0", RevisionAsBuild, "1.2.3"},
+		{"1.2.3.0", RevisionAsPreRelease, "1.2.3"},
+	}
+	for _, test := range tests {
+		v, _ := ParseFourPartVersion(test.fourPart)
+		semVer, err := v.ToSemVer(test.mapping)
+		assert.NoError(t, err)
+		assert.Equal(t, test.semVer, semVer.String())
+
+		parsed, err := ParseSemVersion(test.semVer)
+		assert.NoError(t, err)
+		fourPart, err := parsed.ToFourPart(test.mapping)
+		assert.NoError(t, err)
+		assert.Equal(t, test.fourPart, fourPart.String())
+	}
+
+	for _, input := range []string{"1.2.3+build.4", "1.2.3-rc.1"} {
+		v, _ := ParseSemVersion(input)
+		_, err := v.ToFourPart(RevisionAsBuild)
+		assert.Error(t, err, "expected an error for version %s", input)
+	}
+	for _, input := range []string{"1.2.3-beta", "1.2.3-r", "1.2.3-r.1.1", "1.2.3-r4"} {
+		v, _ := ParseSemVersion(input)
+		_, err := v.ToFourPart(RevisionAsPreRelease)
+		assert.Error(t, err, "expected an error for version %s", input)
+	}
+
+	// the pre-release mapping keeps the revisions ordered
+	r9, _ := ParseFourPartVersion("1.2.3.9")
+	r10, _ := ParseFourPartVersion("1.2.3.10")
+	semVer9, _ := r9.ToSemVer(RevisionAsPreRelease)
+	semVer10, _ := r10.ToSemVer(RevisionAsPreRelease)
+	assert.Equal(t, -1, semVer9.Compare(semVer10))
+	// unlike the unseparated form, whose single identifier is compared lexically
+	assert.Equal(t, 1, MustParse("1.2.3-r9").Compare(MustParse("1.2.3-r10")))
+}
+
+func TestBumpAfterRevisionMapping(t *testing.T) {
+	tests := []struct {
+		fourPart string
+		mapping  RevisionMapping
+		strategy BumpStrategy
+		expected string
+	}{
+		{"1.2.3.4", RevisionAsBuild, PreReleaseBuild, "1.2.3+5"},
+		{"1.2.3.4", RevisionAsBuild, PreRelease, "1.2.3-alpha"},
+		{"1.2.3.0", RevisionAsBuild, PreReleaseBuild, "1.2.3+build.1"},
+		{"1.2.3.4", RevisionAsPreRelease, Release, "1.2.3"},
+		{"1.2.3.0", RevisionAsPreRelease, PreRelease, "1.2.3-alpha"},
+	}
+	for _, test := range tests {
+		v, _ := ParseFourPartVersion(test.fourPart)
+		semVer, err := v.ToSemVer(test.mapping)
+		assert.NoError(t, err)
+		bumped, err := semVer.Bump(test.strategy, []string{"alpha", "beta"}, "build")
+		assert.NoError(t, err, "%s of %s", test.strategy, semVer)
+		assert.Equal(t, test.expected, bumped.String(), "%s of %s", test.strategy, semVer)
+	}
+}
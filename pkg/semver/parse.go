@@ -0,0 +1,236 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParseMode controls how strictly version strings are parsed.
+type ParseMode string
+
+const (
+	// ModeStrict only accepts the syntax of the Semantic Versioning 2.0.0 specification: leading zeros, empty
+	// identifiers, missing parts and prefixes are rejected. Like every mode, it only supports pre-release versions of
+	// the form `label.N.N.N` (an optional label followed by up to three numbers), so spec-valid pre-releases such as
+	// 1.0.0-alpha.beta or 1.0.0-x.7.z.92 are rejected as unsupported
+	ModeStrict ParseMode = "strict"
+	// ModeLenient accepts a `v` prefix, surrounding whitespace, missing minor and patch versions (e.g. "v1.2" is
+	// 1.2.0) and leading zeros, which are removed
+	ModeLenient ParseMode = "lenient"
+)
+
+// ParseError is the error of a version string that can't be parsed. Position is the byte offset of the offending
+// character in the input.
+type ParseError struct {
+	Input    string
+	Position int
+	Message  string
+}
+
+// Error returns the error message, including the position of the offending character
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid version '%s': %s at position %d", e.Input, e.Message, e.Position)
+}
+
+// Parse parses a version string in the given ParseMode and returns a new SemanticVersion instance. Errors are
+// returned as a *ParseError.
+func Parse(versionStr string, mode ParseMode) (*SemanticVersion, error) {
+	if mode != ModeStrict && mode != ModeLenient {
+		return nil, fmt.Errorf("invalid parse mode: %s", mode)
+	}
+	p := &parser{input: versionStr, lenient: mode == ModeLenient}
+	return p.parse()
+}
+
+// ParseLenient parses a version string in the lenient ParseMode, e.g. "v1.2" is 1.2.0.
+func ParseLenient(versionStr string) (*SemanticVersion, error) {
+	return Parse(versionStr, ModeLenient)
+}
+
+// coercePattern matches the first version-like sequence of numbers in a text
+var coercePattern = regexp.MustCompile(`(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// Coerce returns the first version found in arbitrary text, e.g. "release v1.2 (final)" is 1.2.0. Missing minor and
+// patch versions are 0, and any pre-release or build version is ignored.
+func Coerce(text string) (*SemanticVersion, error) {
+	m := coercePattern.FindStringSubmatch(text)
+	if m == nil {
+		return nil, fmt.Errorf("no version found in: %s", text)
+	}
+	parts := make([]int, 3)
+	for i, part := range m[1:] {
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("version number out of range in: %s", text)
+		}
+		parts[i] = n
+	}
+	return ParseSemVersion(newVersion(parts[0], parts[1], parts[2]).String())
+}
+
+// parser scans a version string, reporting the position of any error
+type parser struct {
+	input   string
+	pos     int
+	lenient bool
+}
+
+// errorf returns a ParseError at the given position
+func (p *parser) errorf(pos int, format string, args ...interface{}) *ParseError {
+	return &ParseError{Input: p.input, Position: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// peek returns the current character, or 0 at the end of the input
+func (p *parser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) parse() (*SemanticVersion, error) {
+	end := len(p.input)
+	if p.lenient {
+		end = len(strings.TrimRight(p.input, " \t\r\n"))
+		for p.pos < end && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+			p.pos++
+		}
+		if c := p.peek(); c == 'v' || c == 'V' {
+			p.pos++
+		}
+	}
+	if p.pos == end {
+		return nil, p.errorf(p.pos, "empty version")
+	}
+
+	parts := make([]int, 3)
+	for i, name := range []string{"major", "minor", "patch"} {
+		if i > 0 {
+			if p.peek() != '.' {
+				if p.lenient {
+					break
+				}
+				return nil, p.errorf(p.pos, "missing %s version", name)
+			}
+			p.pos++
+		}
+		n, err := p.number(name)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = n
+	}
+	version := newVersion(parts[0], parts[1], parts[2])
+
+	var preReleaseStr, buildStr string
+	preReleasePos, buildPos := p.pos, p.pos
+	if p.pos < end && p.peek() == '-' {
+		p.pos++
+		preReleasePos = p.pos
+		var err error
+		if preReleaseStr, err = p.identifiers("pre-release", end); err != nil {
+			return nil, err
+		}
+	}
+	if p.pos < end && p.peek() == '+' {
+		p.pos++
+		buildPos = p.pos
+		var err error
+		if buildStr, err = p.identifiers("build", end); err != nil {
+			return nil, err
+		}
+	}
+	if p.pos < end {
+		if p.peek() == '.' {
+			return nil, p.errorf(p.pos, "too many version parts")
+		}
+		return nil, p.errorf(p.pos, "unexpected character '%c'", p.peek())
+	}
+
+	preReleaseVersion, err := parsePrereleaseVersion(preReleaseStr)
+	if err != nil {
+		return nil, p.errorf(preReleasePos, "unsupported pre-release version '%s'", preReleaseStr)
+	}
+	build, err := parseBuild(buildStr)
+	if err != nil {
+		return nil, p.errorf(buildPos, "%v", err)
+	}
+	return &SemanticVersion{
+		rootVersion:       version,
+		preReleaseVersion: preReleaseVersion,
+		buildVersion:      build,
+	}, nil
+}
+
+// number scans a version number. Leading zeros are only accepted in lenient mode.
+func (p *parser) number(name string) (int, error) {
+	start := p.pos
+	for p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	digits := p.input[start:p.pos]
+	if digits == "" {
+		if p.pos == len(p.input) {
+			return 0, p.errorf(p.pos, "missing %s version", name)
+		}
+		return 0, p.errorf(p.pos, "unexpected character '%c' in %s version", p.peek(), name)
+	}
+	if !p.lenient && len(digits) > 1 && digits[0] == '0' {
+		return 0, p.errorf(start, "leading zero in %s version", name)
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, p.errorf(start, "%s version out of range", name)
+	}
+	return n, nil
+}
+
+// identifiers scans the dot-separated pre-release or build identifiers up to the next '+' or `end`. Numeric
+// pre-release identifiers can only have leading zeros in lenient mode.
+func (p *parser) identifiers(kind string, end int) (string, error) {
+	start := p.pos
+	for {
+		idStart := p.pos
+		for p.pos < end && isIdentifierChar(p.peek()) {
+			p.pos++
+		}
+		id := p.input[idStart:p.pos]
+		if id == "" {
+			if p.pos < end && p.peek() != '.' && p.peek() != '+' {
+				return "", p.errorf(p.pos, "invalid character '%c' in %s version", p.peek(), kind)
+			}
+			return "", p.errorf(p.pos, "empty %s identifier", kind)
+		}
+		if kind == "pre-release" && !p.lenient && len(id) > 1 && id[0] == '0' && isNumeric(id) {
+			return "", p.errorf(idStart, "leading zero in pre-release identifier '%s'", id)
+		}
+		if p.pos < end && p.peek() == '.' {
+			p.pos++
+			continue
+		}
+		if p.pos < end && !(kind == "pre-release" && p.peek() == '+') {
+			return "", p.errorf(p.pos, "invalid character '%c' in %s version", p.peek(), kind)
+		}
+		return p.input[start:p.pos], nil
+	}
+}
+
+// isIdentifierChar returns true for the characters allowed in pre-release and build identifiers
+func isIdentifierChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-'
+}
+
+// isNumeric returns true if the identifier only has digits
+func isNumeric(id string) bool {
+	for i := 0; i < len(id); i++ {
+		if id[i] < '0' || id[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStrict(t *testing.T) {
+	tests := []struct {
+		input    string
+		position int
+		message  string
+	}{
+		{"", 0, "empty version"},
+		{"v1.2.3", 0, "unexpected character 'v' in major version"},
+		{"01.2.3", 0, "leading zero in major version"},
+		{"1.02.3", 2, "leading zero in minor version"},
+		{"1.2", 3, "missing patch version"},
+		{"1.2.", 4, "missing patch version"},
+		{"1.2.3.4", 5, "too many version parts"},
+		{"1.2.3-", 6, "empty pre-release identifier"},
+		{"1.2.3-alpha..1", 12, "empty pre-release identifier"},
+		{"1.2.3-alpha.01", 12, "leading zero in pre-release identifier '01'"},
+		{"1.2.3-alpha_1", 11, "invalid character '_' in pre-release version"},
+		{"1.2.3+", 6, "empty build identifier"},
+		{"1.2.3+build.1+2", 13, "invalid character '+' in build version"},
+		{" 1.2.3", 0, "unexpected character ' ' in major version"},
+		{"1.2.3-rc.1.dev.7", 6, "unsupported pre-release version 'rc.1.dev.7'"},
+		// valid in the specification, but only label.N.N.N pre-releases are supported
+		{"1.0.0-alpha.beta", 6, "unsupported pre-release version 'alpha.beta'"},
+		{"1.0.0-x.7.z.92", 6, "unsupported pre-release version 'x.7.z.92'"},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.input, ModeStrict)
+		var parseErr *ParseError
+		if assert.True(t, errors.As(err, &parseErr), "expected a ParseError for version '%s'", test.input) {
+			assert.Equal(t, test.position, parseErr.Position, "position for version '%s'", test.input)
+			assert.Equal(t, test.message, parseErr.Message, "message for version '%s'", test.input)
+		}
+	}
+
+	// build identifiers may have leading zeros
+	v, err := Parse("1.2.3-alpha.1+build.007", ModeStrict)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3-alpha.1+build.007", v.String())
+
+	_, err = ParseSemVersion("01.2.3")
+	assert.EqualError(t, err, "invalid version '01.2.3': leading zero in major version at position 0")
+	_, err = Parse("1.2.3", "loose")
+	assert.Error(t, err)
+}
+
+func TestParseLenient(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"v1.2.3", "1.2.3"},
+		{"V1.2.3-rc.1", "1.2.3-rc.1"},
+		{"1.2", "1.2.0"},
+		{"v1", "1.0.0"},
+		{"1.2-beta.2+build.1", "1.2.0-beta.2+build.1"},
+		{"01.2.003", "1.2.3"},
+		{"1.2.3-alpha.01", "1.2.3-alpha.1"},
+		{"  v1.2.3\n", "1.2.3"},
+	}
+	for _, test := range tests {
+		v, err := ParseLenient(test.input)
+		assert.NoError(t, err, "unexpected error for version '%s'", test.input)
+		if err == nil {
+			assert.Equal(t, test.expected, v.String())
+		}
+	}
+
+	for _, input := range []string{"", "v", "vv1.2.3", "1.2.3.4", "1..2", "1.2.3-"} {
+		_, err := ParseLenient(input)
+		assert.Error(t, err, "expected an error for version '%s'", input)
+	}
+	_, err := ParseLenient("  x1.2")
+	var parseErr *ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, 2, parseErr.Position)
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"release v1.2 (final)", "1.2.0"},
+		{"version: 42", "42.0.0"},
+		{"go1.22.2 linux/amd64", "1.22.2"},
+		{"1.2.3.4", "1.2.3"},
+		{"1.2.3-rc.1+build.5", "1.2.3"},
+		{"v01.02.03", "1.2.3"},
+	}
+	for _, test := range tests {
+		v, err := Coerce(test.input)
+		assert.NoError(t, err, "unexpected error for text '%s'", test.input)
+		if err == nil {
+			assert.Equal(t, test.expected, v.String())
+		}
+	}
+
+	_, err := Coerce("no version here")
+	assert.Error(t, err)
+}
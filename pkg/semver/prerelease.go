@@ -3,7 +3,6 @@ package semver
 import (
 	"fmt"
 	"github.com/ptgoetz/go-versionbump/internal/utils"
-	"sort"
 	"strconv"
 	"strings"
 )
@@ -24,6 +23,28 @@ func newPrereleaseVersion(label string, major int, minor int, patch int) *PreRel
 	}
 }
 
+// NewPreReleaseVersion creates a new PreReleaseVersion instance from pre-release identifiers, e.g. "rc", "1". The
+// identifiers are an optional label followed by up to three numbers, which must not have leading zeros.
+func NewPreReleaseVersion(identifiers ...string) (*PreReleaseVersion, error) {
+	if len(identifiers) == 0 {
+		return nil, fmt.Errorf("invalid pre-release version, at least one identifier is required")
+	}
+	for _, identifier := range identifiers {
+		if !ValidateBuildIdentifier(identifier) {
+			return nil, fmt.Errorf("invalid pre-release identifier, identifiers must be alphanumeric or '-': '%s'", identifier)
+		}
+		if len(identifier) > 1 && identifier[0] == '0' && isNumeric(identifier) {
+			return nil, fmt.Errorf("invalid pre-release identifier, numbers must not have leading zeros: '%s'", identifier)
+		}
+	}
+	versionStr := strings.Join(identifiers, ".")
+	preRelease, err := parsePrereleaseVersion(versionStr)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported pre-release version '%s': %w", versionStr, err)
+	}
+	return preRelease, nil
+}
+
 // parsePrereleaseVersion parses a rootVersion string and returns a new PreReleaseVersion instance.
 // It handles versions with 1, 2, or 3 parts. E.g., "1" becomes "1.0.0", "1.2" becomes "1.2.0".
 func parsePrereleaseVersion(versionStr string) (*PreReleaseVersion, error) {
@@ -79,23 +100,43 @@ func parsePrereleaseVersion(versionStr string) (*PreReleaseVersion, error) {
 		if err != nil {
 			return nil, fmt.Errorf("invalid patch rootVersion: %s", vals[2])
 		}
+	default:
+		return nil, fmt.Errorf("too many pre-release version parts: %s", versionStr)
 	}
 
 	return newPrereleaseVersion(label, major, minor, patch), nil
 }
 
-// Label returns the pre-release label
+// Label returns the pre-release label, or an empty string if the pre-release version is nil
 func (v *PreReleaseVersion) Label() string {
+	if v == nil {
+		return ""
+	}
 	return v.label
 }
 
-// Version returns the pre-release Version
+// Version returns the pre-release Version, or nil if the pre-release version is nil
 func (v *PreReleaseVersion) Version() *Version {
+	if v == nil {
+		return nil
+	}
 	return v.version
 }
 
-// String returns the reduced rootVersion string by removing trailing ".0" parts
+// Identifiers returns the dot-separated identifiers of the pre-release version, e.g. ["rc", "1"]
+func (v *PreReleaseVersion) Identifiers() []string {
+	if s := v.String(); s != "" {
+		return strings.Split(s, ".")
+	}
+	return nil
+}
+
+// String returns the reduced rootVersion string by removing trailing ".0" parts, or an empty string if the
+// pre-release version is nil
 func (v *PreReleaseVersion) String() string {
+	if v == nil {
+		return ""
+	}
 	var retval string
 	if v.version.patch != 0 {
 		retval = fmt.Sprintf("%d.%d.%d", v.version.major, v.version.minor, v.version.patch)
@@ -117,14 +158,18 @@ func (v *PreReleaseVersion) String() string {
 	return retval
 }
 
-// Compare compares two PreReleaseVersion instances.
+// Compare compares two PreReleaseVersion instances, ordering labels lexically.
 // Returns -1 if v is less than other, 1 if v is greater than other, and 0 if they are equal.
 func (v *PreReleaseVersion) Compare(other *PreReleaseVersion) int {
+	return v.CompareWithLabels(other, nil)
+}
+
+// CompareWithLabels compares two PreReleaseVersion instances, ordering labels by their position in `labels` (e.g.
+// dev < preview < rc). Labels not in `labels` are ordered after the listed labels, lexically.
+// Returns -1 if v is less than other, 1 if v is greater than other, and 0 if they are equal.
+func (v *PreReleaseVersion) CompareWithLabels(other *PreReleaseVersion, labels []string) int {
 	if v.label != other.label {
-		if v.label < other.label {
-			return -1
-		}
-		return 1
+		return compareLabels(v.label, other.label, labels)
 	}
 
 	if v.version.major != other.version.major {
@@ -151,13 +196,41 @@ func (v *PreReleaseVersion) Compare(other *PreReleaseVersion) int {
 	return 0
 }
 
-// bump returns a new PreReleaseVersion instance after incrementing the specified part
+// compareLabels compares two different pre-release labels by their position in `labels`. A version without a label
+// (e.g. 1.0.0-1) orders before any label, as numeric identifiers have a lower precedence than alphanumeric ones.
+func compareLabels(a string, b string, labels []string) int {
+	rank := func(label string) int {
+		if label == "" {
+			return -1
+		}
+		if idx := indexOf(label, labels); idx >= 0 {
+			return idx
+		}
+		return len(labels)
+	}
+
+	rankA, rankB := rank(a), rank(b)
+	switch {
+	case rankA < rankB:
+		return -1
+	case rankA > rankB:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// bump returns a new PreReleaseVersion instance after incrementing the specified part. `preReleaseLabels` are in the
+// order versions progress through them, and are not modified.
 func (v *PreReleaseVersion) bump(versionPart int, preReleaseLabels []string) (*PreReleaseVersion, error) {
 	if len(preReleaseLabels) == 0 {
-		panic("PreReleaseVersion.bump(): preReleaseLabels cannot be empty")
+		return nil, fmt.Errorf("pre-release labels are required to bump a pre-release version")
 	}
-	// sort pre-release labels
-	sort.Strings(preReleaseLabels)
+	// the labels are in the order versions progress through them
 	// if the label is empty, this is the first pre-release rootVersion, so return the first label
 	label := v.label
 	if v.label == "" {
@@ -192,7 +265,7 @@ func (v *PreReleaseVersion) bump(versionPart int, preReleaseLabels []string) (*P
 			return newPrereleaseVersion(preReleaseLabels[idx+offset], 0, 0, 0), nil
 		}
 	default:
-		panic(fmt.Sprintf("invalid rootVersion part: %d.\n", versionPart))
+		return nil, fmt.Errorf("invalid pre-release version part: %d", versionPart)
 	}
 }
 
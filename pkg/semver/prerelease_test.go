@@ -49,6 +49,35 @@ func TestBumpPreRelease(t *testing.T) {
 	}
 }
 
+// TestBumpPreReleaseLabelOrder ensures that labels progress in the given order, and the labels are not modified
+func TestBumpPreReleaseLabelOrder(t *testing.T) {
+	preReleaseLabels := []string{"snapshot", "milestone", "rc"}
+	tests := []struct {
+		input      string
+		expected   string
+		shouldFail bool
+	}{
+		{"1.0.0", "snapshot", false},
+		{"snapshot", "milestone", false},
+		{"milestone.2", "rc", false},
+		{"rc", "", true},
+	}
+
+	for _, test := range tests {
+		subv, err := parsePrereleaseVersion(test.input)
+		assert.NoError(t, err, "unexpected error for input %s", test.input)
+
+		bumped, err := subv.bump(prNext, preReleaseLabels)
+		if test.shouldFail {
+			assert.Error(t, err, "expected an error for rootVersion %s", test.input)
+		} else {
+			assert.NoError(t, err, "unexpected error for rootVersion %s", test.input)
+			assert.Equal(t, test.expected, bumped.String())
+		}
+	}
+	assert.Equal(t, []string{"snapshot", "milestone", "rc"}, preReleaseLabels, "expected labels to be unchanged")
+}
+
 func TestParsePrereleaseVersion(t *testing.T) {
 	tests := []struct {
 		versionStr string
@@ -5,6 +5,7 @@ package semver
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -24,6 +25,7 @@ const (
 	prMinor
 	prPatch
 	prBuild
+	vRevision
 )
 
 type BumpStrategy string
@@ -41,40 +43,44 @@ const (
 	PreReleaseNewMajor BumpStrategy = "new-pre-major"
 	PreReleaseNewMinor BumpStrategy = "new-pre-minor"
 	PreReleaseNewPatch BumpStrategy = "new-pre-patch"
+	Revision           BumpStrategy = "revision"
 )
 
 func (b BumpStrategy) String() string {
 	return string(b)
 }
 
-func versionPartInt(part BumpStrategy) int {
+// versionPartInt returns the version part a built-in strategy bumps, or false if the strategy isn't built-in
+func versionPartInt(part BumpStrategy) (int, bool) {
 	switch part {
 	case Major:
-		return vMajor
+		return vMajor, true
 	case Minor:
-		return vMinor
+		return vMinor, true
 	case Patch:
-		return vPatch
+		return vPatch, true
 	case Release:
-		return vRelease
+		return vRelease, true
 	case PreRelease:
-		return prNext
+		return prNext, true
 	case PreReleaseMajor:
-		return prMajor
+		return prMajor, true
 	case PreReleaseMinor:
-		return prMinor
+		return prMinor, true
 	case PreReleasePatch:
-		return prPatch
+		return prPatch, true
 	case PreReleaseBuild:
-		return prBuild
+		return prBuild, true
 	case PreReleaseNewMajor:
-		return prNewMajor
+		return prNewMajor, true
 	case PreReleaseNewMinor:
-		return prNewMinor
+		return prNewMinor, true
 	case PreReleaseNewPatch:
-		return prNewPatch
+		return prNewPatch, true
+	case Revision:
+		return vRevision, true
 	default:
-		panic(fmt.Sprintf("invalid rootVersion part: %s", part))
+		return 0, false
 	}
 }
 
@@ -95,14 +101,20 @@ func (v *SemanticVersion) PreReleaseVersion() *PreReleaseVersion {
 	return v.preReleaseVersion
 }
 
+// IsPreRelease returns true if the SemanticVersion has a pre-release version
+func (v *SemanticVersion) IsPreRelease() bool {
+	return !v.IsZero() && v.preReleaseVersion != nil && v.preReleaseVersion.String() != ""
+}
+
 // BuildVersion returns the BuildVersion part of the SemanticVersion
 func (v *SemanticVersion) BuildVersion() *BuildVersion {
 	return v.buildVersion
 }
 
-// String returns the string representation of the SemanticVersion instance
+// String returns the string representation of the SemanticVersion instance, or an empty string if it is nil or the
+// zero value
 func (v *SemanticVersion) String() string {
-	if v == nil {
+	if v.IsZero() {
 		return ""
 	}
 	version := v.rootVersion.String()
@@ -116,14 +128,35 @@ func (v *SemanticVersion) String() string {
 }
 
 // Bump returns a new SemanticVersion instance after applying the specified BumpStrategy.
-// If the strategy is a pre-release strategy, `preReleaseLabels` must be provided. If the strategy is a build strategy,
-// `buildLabel` must be provided. If the strategy is a root version strategy, preReleaseLabels and buildLabel are ignored.
+// If the strategy is a pre-release strategy, `preReleaseLabels` must be provided, otherwise an error is returned. If the
+// strategy is a build strategy, `buildLabel` must be provided. If the strategy is a root version strategy,
+// preReleaseLabels and buildLabel are ignored.
+// Strategies that aren't built-in are looked up in the strategy registry (see RegisterStrategy).
 func (v *SemanticVersion) Bump(strategy BumpStrategy, preReleaseLabels []string, buildLabel string) (*SemanticVersion, error) {
 	var version *Version
 	var preReleaseVersion *PreReleaseVersion
 	var build *BuildVersion
 	var err error
-	versionPart := versionPartInt(strategy)
+	if v.IsZero() {
+		return nil, errZeroVersion
+	}
+	versionPart, ok := versionPartInt(strategy)
+	if !ok {
+		if registered, found := LookupStrategy(strategy.String()); found {
+			return registered.Apply(v, StrategyConfig{PreReleaseLabels: preReleaseLabels, BuildLabel: buildLabel})
+		}
+		return nil, fmt.Errorf("unknown version strategy: %s", strategy)
+	}
+
+	if versionPart == vRevision {
+		if !v.rootVersion.fourPart {
+			return nil, fmt.Errorf("the revision strategy requires a four-part version: %s", v)
+		}
+		return &SemanticVersion{rootVersion: v.rootVersion.bump(vRevision)}, nil
+	}
+	if v.rootVersion.fourPart && versionPart > vRelease {
+		return nil, fmt.Errorf("four-part versions don't have pre-release or build versions: %s", strategy)
+	}
 
 	switch {
 	case versionPart >= vMajor && versionPart <= vPatch:
@@ -132,6 +165,9 @@ func (v *SemanticVersion) Bump(strategy BumpStrategy, preReleaseLabels []string,
 		// reset all pre-release versions
 		preReleaseVersion = newPrereleaseVersion("", 0, 0, 0)
 	case versionPart >= prNewMajor && versionPart <= prNewPatch:
+		if len(preReleaseLabels) == 0 {
+			return nil, fmt.Errorf("pre-release labels are required for the %s strategy", strategy)
+		}
 		// bump the root version
 		version = v.rootVersion.bump(versionPart - 6)
 		// reset all pre-release versions
@@ -145,14 +181,15 @@ func (v *SemanticVersion) Bump(strategy BumpStrategy, preReleaseLabels []string,
 	case versionPart == prBuild:
 		version = newVersion(v.rootVersion.major, v.rootVersion.minor, v.rootVersion.patch)
 		preReleaseVersion = newPrereleaseVersion(v.preReleaseVersion.label, v.preReleaseVersion.version.major, v.preReleaseVersion.version.minor, v.preReleaseVersion.version.patch)
-		if v.buildVersion != nil {
+		if v.buildVersion != nil && v.buildVersion.isCounter() {
 			build = v.buildVersion.bump()
 		} else {
 			build = newBuild(buildLabel, 1)
 		}
 	case versionPart == vRelease:
 		version = v.rootVersion
-		preReleaseVersion = nil
+		// an empty pre-release version, so the release can be bumped further (e.g. in a chain)
+		preReleaseVersion = newPrereleaseVersion("", 0, 0, 0)
 		build = nil
 	default:
 		return nil, fmt.Errorf("invalid version strategy: %d", versionPart)
@@ -165,9 +202,55 @@ func (v *SemanticVersion) Bump(strategy BumpStrategy, preReleaseLabels []string,
 	}, nil
 }
 
-// Compare compares two SemanticVersion instances.
+// BumpChannel returns a new SemanticVersion on the pre-release channel with the given label. Each channel is numbered
+// independently: bumping within the same channel increments its number (1.2.0-beta.1 -> 1.2.0-beta.2), while switching
+// channels starts the number at 1 (1.2.0-nightly.5 -> 1.2.0-beta.1). A release starts the channel on the next patch
+// version (1.2.0 -> 1.2.1-beta.1). Build metadata is dropped.
+func (v *SemanticVersion) BumpChannel(label string) (*SemanticVersion, error) {
+	if v.IsZero() {
+		return nil, errZeroVersion
+	}
+	if label == "" || !ValidatePreReleaseLabels([]string{label}) {
+		return nil, fmt.Errorf("invalid channel label, the label must be alphabetic: %s", label)
+	}
+	if v.rootVersion.fourPart {
+		return nil, fmt.Errorf("four-part versions don't have pre-release versions: %s", v)
+	}
+	version := newVersion(v.rootVersion.major, v.rootVersion.minor, v.rootVersion.patch)
+	number := 1
+	if !v.IsPreRelease() {
+		version = v.rootVersion.bump(vPatch)
+	} else if v.preReleaseVersion.label == label {
+		number = v.preReleaseVersion.version.major + 1
+	}
+
+	return &SemanticVersion{
+		rootVersion:       version,
+		preReleaseVersion: newPrereleaseVersion(label, number, 0, 0),
+	}, nil
+}
+
+// Compare compares two SemanticVersion instances, ordering pre-release labels lexically.
 // Returns -1 if v is less than other, 1 if v is greater than other, and 0 if they are equal.
 func (v *SemanticVersion) Compare(other *SemanticVersion) int {
+	return v.CompareWithLabels(other, nil)
+}
+
+// CompareWithLabels compares two SemanticVersion instances, ordering pre-release labels by their position in
+// `labels`. Labels not in `labels` are ordered after the listed labels, lexically.
+// Returns -1 if v is less than other, 1 if v is greater than other, and 0 if they are equal.
+func (v *SemanticVersion) CompareWithLabels(other *SemanticVersion, labels []string) int {
+	// the zero value precedes every version
+	if v.IsZero() || other.IsZero() {
+		switch {
+		case v.IsZero() && other.IsZero():
+			return 0
+		case v.IsZero():
+			return -1
+		default:
+			return 1
+		}
+	}
 	if v.rootVersion.major != other.rootVersion.major {
 		if v.rootVersion.major < other.rootVersion.major {
 			return -1
@@ -189,14 +272,23 @@ func (v *SemanticVersion) Compare(other *SemanticVersion) int {
 		return 1
 	}
 
-	if v.preReleaseVersion != nil && other.preReleaseVersion != nil {
-		preReleaseComparison := v.preReleaseVersion.Compare(other.preReleaseVersion)
+	// a three-part version has revision 0
+	if v.rootVersion.revision != other.rootVersion.revision {
+		if v.rootVersion.revision < other.rootVersion.revision {
+			return -1
+		}
+		return 1
+	}
+
+	// a release has a higher precedence than its pre-releases
+	if v.IsPreRelease() && other.IsPreRelease() {
+		preReleaseComparison := v.preReleaseVersion.CompareWithLabels(other.preReleaseVersion, labels)
 		if preReleaseComparison != 0 {
 			return preReleaseComparison
 		}
-	} else if v.preReleaseVersion != nil {
+	} else if v.IsPreRelease() {
 		return -1
-	} else if other.preReleaseVersion != nil {
+	} else if other.IsPreRelease() {
 		return 1
 	}
 
@@ -214,50 +306,10 @@ func (v *SemanticVersion) Compare(other *SemanticVersion) int {
 	return 0
 }
 
-// ParseSemVersion parses a semantic rootVersion string and returns a new SemanticVersion instance
+// ParseSemVersion parses a semantic rootVersion string in the strict ParseMode and returns a new SemanticVersion
+// instance
 func ParseSemVersion(versionStr string) (*SemanticVersion, error) {
-	isPreRelease := strings.Contains(versionStr, "-")
-	isBuild := strings.Contains(versionStr, "+")
-
-	var rootPart string
-	var preReleasePart string
-	var buildPart string
-	if !isPreRelease && !isBuild {
-		rootPart = versionStr
-	} else if isPreRelease && isBuild {
-		parts := strings.Split(versionStr, "-")
-		rootPart = parts[0]
-		prAndBuildParts := strings.Split(parts[1], "+")
-		preReleasePart = prAndBuildParts[0]
-		buildPart = prAndBuildParts[1]
-	} else if isBuild && !isPreRelease {
-		parts := strings.Split(versionStr, "+")
-		rootPart = parts[0]
-		buildPart = parts[1]
-	} else if !isBuild && isPreRelease { // pre-release and no BuildVersion
-		parts := strings.Split(versionStr, "-")
-		rootPart = parts[0]
-		preReleasePart = parts[1]
-	}
-
-	version, err := parseVersion(rootPart)
-	if err != nil {
-		return nil, err
-	}
-	preReleaseVersion, err := parsePrereleaseVersion(preReleasePart)
-	if err != nil {
-		return nil, err
-	}
-
-	build, err := parseBuild(buildPart)
-	if err != nil {
-		return nil, err
-	}
-	return &SemanticVersion{
-		rootVersion:       version,
-		preReleaseVersion: preReleaseVersion,
-		buildVersion:      build,
-	}, nil
+	return Parse(versionStr, ModeStrict)
 }
 
 // ValidateSemVersion checks if the provided string is a valid semantic version
@@ -266,6 +318,17 @@ func ValidateSemVersion(versionStr string) bool {
 	return err == nil
 }
 
+// specPattern is the regular expression for versions suggested by the Semantic Versioning 2.0.0 specification
+var specPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// IsSpecCompliant checks if the provided string is a version as defined by the Semantic Versioning 2.0.0
+// specification. Unlike ValidateSemVersion, any pre-release identifiers are allowed (e.g. 1.2.3-rc.1.dev.7).
+func IsSpecCompliant(versionStr string) bool {
+	return specPattern.MatchString(versionStr)
+}
+
 // ValidatePreReleaseLabels checks if the provided pre-release labels are valid
 func ValidatePreReleaseLabels(preReleaseLabels []string) bool {
 	for _, label := range preReleaseLabels {
@@ -290,7 +353,13 @@ func ValidatePreReleaseLabelsString(preReleaseLabels string) bool {
 
 // SortVersions sorts a slice of SemanticVersion instances in descending order (latest to oldest)
 func SortVersions(versions []*SemanticVersion) {
-	sort.Slice(versions, func(i, j int) bool {
-		return versions[i].Compare(versions[j]) > 0
+	SortVersionsWithLabels(versions, nil)
+}
+
+// SortVersionsWithLabels sorts a slice of SemanticVersion instances in descending order (latest to oldest), ordering
+// pre-release labels by their position in `labels` (see CompareWithLabels).
+func SortVersionsWithLabels(versions []*SemanticVersion, labels []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].CompareWithLabels(versions[j], labels) > 0
 	})
 }
@@ -19,6 +19,8 @@ func TestParseSemVersion(t *testing.T) {
 		{"1.0.0-alpha.1", "1.0.0-alpha.1", false},
 		{"1.0.0-alpha+build.1", "1.0.0-alpha+build.1", false},
 		{"1.0.0+build.1", "1.0.0+build.1", false},
+		{"1.4.0-rc.2+sha.1a2b3c4.20241016", "1.4.0-rc.2+sha.1a2b3c4.20241016", false},
+		{"1.4.0+ci-42.dirty", "1.4.0+ci-42.dirty", false},
 		{"2.0", "", true},     // Should fail as it's not a valid semantic rootVersion
 		{"", "", true},        // Empty rootVersion string should fail
 		{"1.2.3.4", "", true}, // Invalid semver format
@@ -63,6 +65,7 @@ func TestSemVersion_Bump(t *testing.T) {
 		{"1.0.0-beta", PreReleaseMajor, "1.0.0-beta.1", false},
 		{"1.0.0-alpha", PreReleaseBuild, "1.0.0-alpha+ptgoetz.1", false},
 		{"1.0.0-alpha+ptgoetz.1", PreReleaseBuild, "1.0.0-alpha+ptgoetz.2", false},
+		{"1.0.0-alpha+sha.1a2b3c4", PreReleaseBuild, "1.0.0-alpha+ptgoetz.1", false},
 		{"1.0.0-beta", PreReleasePatch, "1.0.0-beta.0.0.1", false},
 		{"1.0.0-beta", PreReleaseMinor, "1.0.0-beta.0.1", false},
 		{"1.0.0", PreReleaseNewMajor, "2.0.0-alpha", false},
@@ -98,6 +101,37 @@ func TestSemVersion_Bump(t *testing.T) {
 	}
 }
 
+func TestSemVersion_BumpChannel(t *testing.T) {
+	tests := []struct {
+		input    string
+		label    string
+		expected string
+	}{
+		{"1.2.0", "beta", "1.2.1-beta.1"},
+		{"1.2.0-beta", "beta", "1.2.0-beta.1"},
+		{"1.2.0-beta.3", "beta", "1.2.0-beta.4"},
+		{"1.2.0-beta.3.1", "beta", "1.2.0-beta.4"},
+		{"1.2.0-nightly.5", "beta", "1.2.0-beta.1"},
+		{"1.2.0-beta.4", "nightly", "1.2.0-nightly.1"},
+		{"1.2.0-nightly.5+build.2", "nightly", "1.2.0-nightly.6"},
+	}
+
+	for _, test := range tests {
+		version, err := ParseSemVersion(test.input)
+		assert.NoError(t, err, "unexpected error for version %s", test.input)
+		bumped, err := version.BumpChannel(test.label)
+		assert.NoError(t, err, "unexpected error for version %s", test.input)
+		assert.Equal(t, test.expected, bumped.String())
+		assert.Equal(t, true, sv.IsValid("v"+bumped.String()), "expected a valid semver version")
+	}
+
+	version, _ := ParseSemVersion("1.2.0")
+	_, err := version.BumpChannel("beta1")
+	assert.Error(t, err, "expected an error for a non-alphabetic label")
+	_, err = version.BumpChannel("")
+	assert.Error(t, err, "expected an error for an empty label")
+}
+
 func TestSemVersion_Compare(t *testing.T) {
 	tests := []struct {
 		version1 string
@@ -117,6 +151,9 @@ func TestSemVersion_Compare(t *testing.T) {
 		{"1.0.0-alpha+build.1", "1.0.0-alpha+build.2", -1},
 		{"1.0.0-alpha+build.2", "1.0.0-alpha+build.1", 1},
 		{"1.0.0-alpha+build.1", "1.0.0-alpha+build.1", 0},
+		{"1.0.0-rc", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc", 1},
+		{"1.0.0-1", "1.0.0-alpha", -1},
 	}
 
 	for _, test := range tests {
@@ -133,6 +170,56 @@ func TestSemVersion_Compare(t *testing.T) {
 	}
 }
 
+func TestSemVersion_CompareWithLabels(t *testing.T) {
+	labels := []string{"dev", "preview", "rc"}
+	tests := []struct {
+		version1 string
+		version2 string
+		expected int
+	}{
+		{"1.0.0-dev", "1.0.0-preview", -1},
+		{"1.0.0-preview", "1.0.0-dev", 1},
+		{"1.0.0-preview.2", "1.0.0-rc", -1},
+		{"1.0.0-rc", "1.0.0", -1},
+		{"1.0.0-preview", "1.0.0-preview", 0},
+		{"1.0.0-preview.1", "1.0.0-preview.2", -1},
+		// labels that aren't configured follow the configured labels, lexically
+		{"1.0.0-rc", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+		{"1.1.0-dev", "1.0.0-rc", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s vs %s", test.version1, test.version2), func(t *testing.T) {
+			semVer1, err := ParseSemVersion(test.version1)
+			assert.NoError(t, err, "unexpected error for rootVersion %s", test.version1)
+
+			semVer2, err := ParseSemVersion(test.version2)
+			assert.NoError(t, err, "unexpected error for rootVersion %s", test.version2)
+
+			result := semVer1.CompareWithLabels(semVer2, labels)
+			assert.Equal(t, test.expected, result, "expected %d, got %d", test.expected, result)
+		})
+	}
+}
+
+func TestSortVersionsWithLabels(t *testing.T) {
+	versions := make([]*SemanticVersion, 0)
+	for _, s := range []string{"1.0.0-dev", "1.0.0", "1.0.0-rc", "1.0.0-preview.1", "0.9.0", "1.0.0-preview"} {
+		v, err := ParseSemVersion(s)
+		assert.NoError(t, err, "unexpected error for version %s", s)
+		versions = append(versions, v)
+	}
+
+	SortVersionsWithLabels(versions, []string{"dev", "preview", "rc"})
+	actual := make([]string, len(versions))
+	for i, v := range versions {
+		actual[i] = v.String()
+	}
+	expected := []string{"1.0.0", "1.0.0-rc", "1.0.0-preview.1", "1.0.0-preview", "1.0.0-dev", "0.9.0"}
+	assert.Equal(t, expected, actual)
+}
+
 func TestAccessors(t *testing.T) {
 	v, err := ParseSemVersion("1.2.3-alpha.4.5.6+build.1")
 	assert.NoError(t, err, "unexpected error for version string  '%s'", "1.2.3-alpha.4.5.6+build.1")
@@ -146,3 +233,22 @@ func TestAccessors(t *testing.T) {
 	assert.Equal(t, "build.1", v.BuildVersion().String(), "expected build version to be 'build.1'")
 	assert.Equal(t, 1, v.BuildVersion().Number(), "expected build number to be 1")
 }
+
+func TestIsSpecCompliant(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected bool
+	}{
+		{"1.2.3", true},
+		{"1.2.3-rc.1.dev.7+g1a2b3c4-dirty", true},
+		{"1.4.0-rc.2+sha.1a2b3c4.20241016", true},
+		{"1.2", false},
+		{"01.2.3", false},
+		{"1.2.3-rc.01", false},
+		{"1.2.3+", false},
+		{"1.2.3-x y", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsSpecCompliant(test.version), "version %s", test.version)
+	}
+}
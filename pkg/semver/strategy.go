@@ -0,0 +1,244 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// StrategyConfig is the configuration a Strategy is applied with.
+type StrategyConfig struct {
+	// PreReleaseLabels are the pre-release labels, in the order versions progress through them
+	PreReleaseLabels []string
+	// BuildLabel is the label of build versions, e.g. `build` in 1.2.3+build.1
+	BuildLabel string
+}
+
+// Strategy is a way to bump a version, e.g. `major`. The built-in strategies are registered for each BumpStrategy,
+// and custom strategies can be added with RegisterStrategy.
+type Strategy interface {
+	// Name returns the name of the strategy, e.g. "major"
+	Name() string
+	// Describe returns a one-line description of the strategy, e.g. "Bump the major version number (e.g. 1.2.3 -> 2.0.0)."
+	Describe() string
+	// Apply returns the bumped version, without modifying `v`
+	Apply(v *SemanticVersion, config StrategyConfig) (*SemanticVersion, error)
+}
+
+// SchemeStrategy is implemented by strategies that only apply to the versions of some schemes, e.g. the revision
+// strategy only applies to four-part versions. Strategies that don't implement it apply to all versions.
+type SchemeStrategy interface {
+	Schemes() []VersionScheme
+}
+
+// builtinStrategy is the Strategy of a BumpStrategy
+type builtinStrategy struct {
+	strategy    BumpStrategy
+	description string
+	schemes     []VersionScheme
+}
+
+func (s builtinStrategy) Name() string {
+	return s.strategy.String()
+}
+
+func (s builtinStrategy) Describe() string {
+	return s.description
+}
+
+func (s builtinStrategy) Apply(v *SemanticVersion, config StrategyConfig) (*SemanticVersion, error) {
+	return v.Bump(s.strategy, config.PreReleaseLabels, config.BuildLabel)
+}
+
+func (s builtinStrategy) Schemes() []VersionScheme {
+	return s.schemes
+}
+
+var (
+	semVerOnly   = []VersionScheme{SchemeSemVer}
+	fourPartOnly = []VersionScheme{SchemeFourPart}
+)
+
+// strategies is the registry of strategies, in the order they were registered
+var strategies = struct {
+	sync.RWMutex
+	list []Strategy
+}{list: []Strategy{
+	builtinStrategy{Major, "Bump the major version number (e.g. 1.2.3 -> 2.0.0).", nil},
+	builtinStrategy{Minor, "Bump the minor version number (e.g. 1.2.3 -> 1.3.0).", nil},
+	builtinStrategy{Patch, "Bump the patch version number (e.g. 1.2.3 -> 1.2.4).", nil},
+	builtinStrategy{Revision, "Bump the revision of a four-part version (e.g. 1.2.3.4 -> 1.2.3.5).", fourPartOnly},
+	builtinStrategy{Release, "Bump the pre-release version to a release version (e.g. 1.2.3-alpha -> 1.2.3).", semVerOnly},
+	builtinStrategy{PreReleaseNewMajor, "Bump the major version and apply the first pre-release label (e.g. 1.2.3 -> 2.0.0-alpha).", semVerOnly},
+	builtinStrategy{PreReleaseNewMinor, "Bump the minor version and apply the first pre-release label (e.g. 1.2.3 -> 1.3.0-alpha).", semVerOnly},
+	builtinStrategy{PreReleaseNewPatch, "Bump the patch version and apply the first pre-release label (e.g. 1.2.3 -> 1.2.4-alpha).", semVerOnly},
+	builtinStrategy{PreRelease, "Bump the next pre-release version label (e.g. 1.2.3-alpha -> 1.2.3-beta).", semVerOnly},
+	builtinStrategy{PreReleaseMajor, "Bump the pre-release major version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.1).", semVerOnly},
+	builtinStrategy{PreReleaseMinor, "Bump the pre-release minor version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.0.1).", semVerOnly},
+	builtinStrategy{PreReleasePatch, "Bump the pre-release patch version number (e.g. 1.2.3-alpha -> 1.2.3-alpha.0.0.1).", semVerOnly},
+	builtinStrategy{PreReleaseBuild, "Bump the pre-release build version number (e.g. 1.2.3 -> 1.2.3+build.1).", semVerOnly},
+}}
+
+// strategyNamePattern matches the valid strategy names, which are also command names
+var strategyNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// ValidateStrategyName checks if the provided string is a valid strategy name: lowercase alphanumerics and hyphens,
+// starting with a letter
+func ValidateStrategyName(name string) bool {
+	return strategyNamePattern.MatchString(name)
+}
+
+// RegisterStrategy adds a custom strategy to the registry. The name must be valid (see ValidateStrategyName) and not
+// already registered.
+func RegisterStrategy(strategy Strategy) error {
+	if !ValidateStrategyName(strategy.Name()) {
+		return fmt.Errorf("invalid strategy name, names must be lowercase alphanumerics or '-': '%s'", strategy.Name())
+	}
+	strategies.Lock()
+	defer strategies.Unlock()
+	for _, s := range strategies.list {
+		if s.Name() == strategy.Name() {
+			return fmt.Errorf("strategy '%s' is already registered", strategy.Name())
+		}
+	}
+	strategies.list = append(strategies.list, strategy)
+	return nil
+}
+
+// LookupStrategy returns the registered strategy with the given name
+func LookupStrategy(name string) (Strategy, bool) {
+	strategies.RLock()
+	defer strategies.RUnlock()
+	for _, s := range strategies.list {
+		if s.Name() == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// Strategies returns the registered strategies, the built-in strategies first
+func Strategies() []Strategy {
+	strategies.RLock()
+	defer strategies.RUnlock()
+	return append([]Strategy{}, strategies.list...)
+}
+
+// Supports returns true if the strategy applies to the version, according to its schemes (see SchemeStrategy)
+func Supports(strategy Strategy, v *SemanticVersion) bool {
+	s, ok := strategy.(SchemeStrategy)
+	if !ok || len(s.Schemes()) == 0 {
+		return true
+	}
+	scheme := SchemeSemVer
+	if v.rootVersion.fourPart {
+		scheme = SchemeFourPart
+	}
+	for _, supported := range s.Schemes() {
+		if supported == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// compositeStrategy applies its steps in order
+type compositeStrategy struct {
+	name        string
+	description string
+	steps       []Strategy
+}
+
+// NewCompositeStrategy returns a Strategy that applies other strategies in order, e.g. `finalize` as `release` then
+// `patch` (1.2.3-rc.1 -> 1.2.3 -> 1.2.4). Without a description, the steps are described.
+func NewCompositeStrategy(name string, description string, steps ...Strategy) (Strategy, error) {
+	if !ValidateStrategyName(name) {
+		return nil, fmt.Errorf("invalid strategy name, names must be lowercase alphanumerics or '-': '%s'", name)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("strategy '%s' requires at least one step", name)
+	}
+	return &compositeStrategy{name: name, description: description, steps: append([]Strategy{}, steps...)}, nil
+}
+
+// StrategyChainSeparator separates the strategies of a chain, e.g. `minor,pre`
+const StrategyChainSeparator = ","
+
+// ChainStrategies returns a Strategy that applies the strategies in order, named after the chain, e.g. `minor,pre`.
+func ChainStrategies(steps ...Strategy) Strategy {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.Name()
+	}
+	return &compositeStrategy{name: strings.Join(names, StrategyChainSeparator), steps: append([]Strategy{}, steps...)}
+}
+
+// SplitStrategyChain returns the names of the strategies of a chain, e.g. `minor,pre` (1.2.3 -> 1.3.0 -> 1.3.0-alpha).
+// Whitespace around the names is ignored.
+func SplitStrategyChain(chain string) ([]string, error) {
+	names := strings.Split(chain, StrategyChainSeparator)
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if names[i] == "" {
+			return nil, fmt.Errorf("empty strategy in chain: '%s'", chain)
+		}
+		if !ValidateStrategyName(names[i]) {
+			return nil, fmt.Errorf("invalid strategy name in chain '%s': '%s'", chain, names[i])
+		}
+	}
+	return names, nil
+}
+
+func (s *compositeStrategy) Name() string {
+	return s.name
+}
+
+func (s *compositeStrategy) Describe() string {
+	if s.description != "" {
+		return s.description
+	}
+	names := make([]string, len(s.steps))
+	for i, step := range s.steps {
+		names[i] = step.Name()
+	}
+	return fmt.Sprintf("Bump with %s.", strings.Join(names, ", then "))
+}
+
+func (s *compositeStrategy) Apply(v *SemanticVersion, config StrategyConfig) (*SemanticVersion, error) {
+	for _, step := range s.steps {
+		bumped, err := step.Apply(v, config)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.name, err)
+		}
+		v = bumped
+	}
+	return v, nil
+}
+
+// Schemes returns the schemes all steps apply to
+func (s *compositeStrategy) Schemes() []VersionScheme {
+	var schemes []VersionScheme
+	for _, scheme := range []VersionScheme{SchemeSemVer, SchemeFourPart} {
+		supported := true
+		for _, step := range s.steps {
+			if st, ok := step.(SchemeStrategy); ok && len(st.Schemes()) > 0 && !containsScheme(st.Schemes(), scheme) {
+				supported = false
+			}
+		}
+		if supported {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes
+}
+
+// containsScheme returns true if the scheme is one of `schemes`
+func containsScheme(schemes []VersionScheme, scheme VersionScheme) bool {
+	for _, s := range schemes {
+		if s == scheme {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// doubleMinor is a custom strategy bumping the minor version twice
+type doubleMinor struct{}
+
+func (doubleMinor) Name() string     { return "double-minor" }
+func (doubleMinor) Describe() string { return "Bump the minor version twice." }
+func (doubleMinor) Apply(v *SemanticVersion, config StrategyConfig) (*SemanticVersion, error) {
+	v, err := v.Bump(Minor, config.PreReleaseLabels, config.BuildLabel)
+	if err != nil {
+		return nil, err
+	}
+	return v.Bump(Minor, config.PreReleaseLabels, config.BuildLabel)
+}
+
+func TestBuiltinStrategies(t *testing.T) {
+	names := make([]string, 0)
+	for _, s := range Strategies() {
+		names = append(names, s.Name())
+	}
+	assert.Subset(t, names, []string{"major", "minor", "patch", "revision", "release", "new-pre-major",
+		"new-pre-minor", "new-pre-patch", "pre", "pre-major", "pre-minor", "pre-patch", "pre-build"})
+	assert.Equal(t, "major", names[0])
+
+	config := StrategyConfig{PreReleaseLabels: []string{"alpha", "beta", "rc"}, BuildLabel: "build"}
+	tests := []struct {
+		strategy string
+		version  string
+		expected string
+	}{
+		{"major", "1.2.3", "2.0.0"},
+		{"new-pre-minor", "1.2.3", "1.3.0-alpha"},
+		{"pre", "1.2.3-alpha", "1.2.3-beta"},
+		{"pre-build", "1.2.3", "1.2.3+build.1"},
+		{"revision", "1.2.3.4", "1.2.3.5"},
+	}
+	for _, test := range tests {
+		strategy, ok := LookupStrategy(test.strategy)
+		assert.True(t, ok, test.strategy)
+		v, err := parseText(test.version)
+		assert.NoError(t, err)
+		bumped, err := strategy.Apply(v, config)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, bumped.String(), "%s of %s", test.strategy, test.version)
+		assert.NotEmpty(t, strategy.Describe())
+	}
+
+	_, ok := LookupStrategy("finalize")
+	assert.False(t, ok)
+}
+
+func TestPreReleaseStrategiesWithoutLabels(t *testing.T) {
+	for _, name := range []BumpStrategy{PreRelease, PreReleaseMajor, PreReleaseMinor, PreReleasePatch,
+		PreReleaseNewMajor, PreReleaseNewMinor, PreReleaseNewPatch} {
+		strategy, ok := LookupStrategy(name.String())
+		assert.True(t, ok, name.String())
+		for _, version := range []string{"1.2.3", "1.2.3-alpha.1"} {
+			_, err := strategy.Apply(MustParse(version), StrategyConfig{})
+			assert.Error(t, err, "%s of %s", name, version)
+		}
+	}
+}
+
+func TestSupports(t *testing.T) {
+	semVer := MustParse("1.2.3")
+	fourPart, err := ParseFourPartVersion("1.2.3.4")
+	assert.NoError(t, err)
+
+	revision, _ := LookupStrategy(Revision.String())
+	pre, _ := LookupStrategy(PreRelease.String())
+	major, _ := LookupStrategy(Major.String())
+	assert.False(t, Supports(revision, semVer))
+	assert.True(t, Supports(revision, fourPart))
+	assert.True(t, Supports(pre, semVer))
+	assert.False(t, Supports(pre, fourPart))
+	assert.True(t, Supports(major, semVer))
+	assert.True(t, Supports(major, fourPart))
+	assert.True(t, Supports(doubleMinor{}, fourPart))
+}
+
+func TestRegisterStrategy(t *testing.T) {
+	assert.NoError(t, RegisterStrategy(doubleMinor{}))
+	assert.Error(t, RegisterStrategy(doubleMinor{}), "duplicate strategy")
+
+	strategy, ok := LookupStrategy("double-minor")
+	assert.True(t, ok)
+	assert.Equal(t, "double-minor", strategy.Name())
+	assert.Equal(t, "double-minor", Strategies()[len(Strategies())-1].Name())
+
+	// Bump falls back to the registered strategies
+	v, err := MustParse("1.2.3").Bump("double-minor", nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0", v.String())
+
+	_, err = MustParse("1.2.3").Bump("unknown", nil, "")
+	assert.EqualError(t, err, "unknown version strategy: unknown")
+
+	composite, err := NewCompositeStrategy("Finalize", "", strategy)
+	assert.Nil(t, composite)
+	assert.Error(t, err)
+}
+
+func TestCompositeStrategy(t *testing.T) {
+	release, _ := LookupStrategy(Release.String())
+	patch, _ := LookupStrategy(Patch.String())
+	revision, _ := LookupStrategy(Revision.String())
+	config := StrategyConfig{PreReleaseLabels: []string{"alpha", "beta", "rc"}}
+
+	finalize, err := NewCompositeStrategy("finalize", "", release, patch)
+	assert.NoError(t, err)
+	assert.Equal(t, "finalize", finalize.Name())
+	assert.Equal(t, "Bump with release, then patch.", finalize.Describe())
+	v, err := finalize.Apply(MustParse("1.2.3-rc.1"), config)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.4", v.String())
+	assert.Equal(t, []VersionScheme{SchemeSemVer}, finalize.(SchemeStrategy).Schemes())
+
+	described, err := NewCompositeStrategy("ship", "Ship it.", finalize, patch)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ship it.", described.Describe())
+
+	// the steps' errors are returned with the name of the strategy
+	broken, err := NewCompositeStrategy("broken", "", patch, revision)
+	assert.NoError(t, err)
+	_, err = broken.Apply(MustParse("1.2.3"), config)
+	assert.EqualError(t, err, "broken: the revision strategy requires a four-part version: 1.2.4")
+	assert.Equal(t, []VersionScheme{SchemeFourPart}, broken.(SchemeStrategy).Schemes())
+
+	_, err = NewCompositeStrategy("empty", "")
+	assert.Error(t, err)
+
+	// pre-release and build steps can follow release
+	pre, _ := LookupStrategy(PreRelease.String())
+	preBuild, _ := LookupStrategy(PreReleaseBuild.String())
+	config.BuildLabel = "build"
+	for _, test := range []struct {
+		step     Strategy
+		expected string
+	}{
+		{pre, "1.2.3-alpha"},
+		{preBuild, "1.2.3+build.1"},
+	} {
+		afterRelease, err := NewCompositeStrategy("after-release", "", release, test.step)
+		assert.NoError(t, err)
+		v, err := afterRelease.Apply(MustParse("1.2.3-rc.1+build.4"), config)
+		assert.NoError(t, err, test.step.Name())
+		assert.Equal(t, test.expected, v.String(), "release, then %s", test.step.Name())
+	}
+}
+
+func TestStrategyChain(t *testing.T) {
+	names, err := SplitStrategyChain("minor, pre")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"minor", "pre"}, names)
+	for _, chain := range []string{"", "minor,", "minor,,pre", "minor,Pre"} {
+		_, err := SplitStrategyChain(chain)
+		assert.Error(t, err, chain)
+	}
+
+	config := StrategyConfig{PreReleaseLabels: []string{"alpha", "beta", "rc"}, BuildLabel: "build"}
+	tests := []struct {
+		chain    []BumpStrategy
+		version  string
+		expected string
+	}{
+		{[]BumpStrategy{Minor, PreRelease}, "1.2.3", "1.3.0-alpha"},
+		{[]BumpStrategy{Release, Patch}, "1.2.3-rc.1", "1.2.4"},
+		{[]BumpStrategy{Release, PreRelease}, "1.2.3-rc.1", "1.2.3-alpha"},
+		{[]BumpStrategy{Release, PreReleaseBuild}, "1.2.3-rc.1", "1.2.3+build.1"},
+	}
+	for _, test := range tests {
+		steps := make([]Strategy, 0)
+		for _, name := range test.chain {
+			step, ok := LookupStrategy(name.String())
+			assert.True(t, ok)
+			steps = append(steps, step)
+		}
+		chain := ChainStrategies(steps...)
+		v, err := chain.Apply(MustParse(test.version), config)
+		assert.NoError(t, err, chain.Name())
+		assert.Equal(t, test.expected, v.String(), "%s of %s", chain.Name(), test.version)
+	}
+
+	minor, _ := LookupStrategy(Minor.String())
+	pre, _ := LookupStrategy(PreRelease.String())
+	assert.Equal(t, "minor,pre", ChainStrategies(minor, pre).Name())
+	assert.Equal(t, "Bump with minor, then pre.", ChainStrategies(minor, pre).Describe())
+}
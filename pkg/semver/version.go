@@ -6,11 +6,14 @@ import (
 	"strings"
 )
 
-// Version represents a semantic rootVersion
+// Version represents a semantic rootVersion. A four-part version (e.g. 1.2.3.4) has a revision in addition to the
+// major, minor and patch versions.
 type Version struct {
-	major int
-	minor int
-	patch int
+	major    int
+	minor    int
+	patch    int
+	revision int
+	fourPart bool
 }
 
 // newVersion creates a new immutable Version instance
@@ -18,6 +21,29 @@ func newVersion(major int, minor int, patch int) *Version {
 	return &Version{major: major, minor: minor, patch: patch}
 }
 
+// newFourPartVersion creates a new immutable four-part Version instance
+func newFourPartVersion(major int, minor int, patch int, revision int) *Version {
+	return &Version{major: major, minor: minor, patch: patch, revision: revision, fourPart: true}
+}
+
+// NewVersion creates a new Version instance from non-negative major, minor and patch versions
+func NewVersion(major int, minor int, patch int) (*Version, error) {
+	if major < 0 || minor < 0 || patch < 0 {
+		return nil, fmt.Errorf("invalid version %d.%d.%d, version numbers must not be negative", major, minor, patch)
+	}
+	return newVersion(major, minor, patch), nil
+}
+
+// NewFourPartVersion creates a new four-part Version instance from non-negative major, minor, patch and revision
+// versions
+func NewFourPartVersion(major int, minor int, patch int, revision int) (*Version, error) {
+	if major < 0 || minor < 0 || patch < 0 || revision < 0 {
+		return nil, fmt.Errorf("invalid version %d.%d.%d.%d, version numbers must not be negative",
+			major, minor, patch, revision)
+	}
+	return newFourPartVersion(major, minor, patch, revision), nil
+}
+
 // parseVersion parses a rootVersion string and returns a new Version instance
 func parseVersion(version string) (*Version, error) {
 	vals := strings.Split(version, ".")
@@ -39,36 +65,102 @@ func parseVersion(version string) (*Version, error) {
 	return newVersion(major, minor, patch), nil
 }
 
-// String returns the string representation of the Version instance (e.g. "1.2.3")
+// parseFourPartVersion parses a four-part rootVersion string (e.g. "1.2.3.4") and returns a new Version instance
+func parseFourPartVersion(version string) (*Version, error) {
+	vals := strings.Split(version, ".")
+	if len(vals) != 4 {
+		return nil, fmt.Errorf("invalid four-part version string: %s", version)
+	}
+	parts := make([]int, len(vals))
+	for i, val := range vals {
+		// digits only, without a sign or leading zeros
+		if !isNumeric(val) || (len(val) > 1 && val[0] == '0') {
+			return nil, fmt.Errorf("invalid four-part version string: %s", version)
+		}
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid four-part version string: %s", version)
+		}
+		parts[i] = n
+	}
+	return newFourPartVersion(parts[0], parts[1], parts[2], parts[3]), nil
+}
+
+// String returns the string representation of the Version instance (e.g. "1.2.3", or "1.2.3.4" for a four-part
+// version), or an empty string if it is nil
 func (v *Version) String() string {
+	if v == nil {
+		return ""
+	}
+	if v.fourPart {
+		return fmt.Sprintf("%d.%d.%d.%d", v.major, v.minor, v.patch, v.revision)
+	}
 	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
 }
 
-// bump returns a new Version instance after incrementing the specified part
+// bump returns a new Version instance after incrementing the specified part. A four-part version stays a four-part
+// version, with the revision reset unless it is incremented.
 func (v *Version) bump(versionPart int) *Version {
+	var bumped *Version
 	switch versionPart {
 	case vMajor:
-		return newVersion(v.major+1, 0, 0)
+		bumped = newVersion(v.major+1, 0, 0)
 	case vMinor:
-		return newVersion(v.major, v.minor+1, 0)
+		bumped = newVersion(v.major, v.minor+1, 0)
 	case vPatch:
-		return newVersion(v.major, v.minor, v.patch+1)
+		bumped = newVersion(v.major, v.minor, v.patch+1)
+	case vRevision:
+		if !v.fourPart {
+			panic(fmt.Sprintf("invalid rootVersion part for a three-part version: %d.\n", versionPart))
+		}
+		return newFourPartVersion(v.major, v.minor, v.patch, v.revision+1)
 	default:
 		panic(fmt.Sprintf("invalid rootVersion part: %d.\n", versionPart))
 	}
+	bumped.fourPart = v.fourPart
+	return bumped
 }
 
-// Major returns the major version part. For example, for version "1.2.3", the major part is 1
+// Major returns the major version part, or 0 if the Version is nil. For example, for version "1.2.3", the major part
+// is 1
 func (v *Version) Major() int {
+	if v == nil {
+		return 0
+	}
 	return v.major
 }
 
-// Minor returns the minor version part. For example, for version "1.2.3", the minor part is 2
+// Minor returns the minor version part, or 0 if the Version is nil. For example, for version "1.2.3", the minor part
+// is 2
 func (v *Version) Minor() int {
+	if v == nil {
+		return 0
+	}
 	return v.minor
 }
 
-// Patch returns the patch version part. For example, for version "1.2.3", the patch part is 3
+// Patch returns the patch version part, or 0 if the Version is nil. For example, for version "1.2.3", the patch part
+// is 3
 func (v *Version) Patch() int {
+	if v == nil {
+		return 0
+	}
 	return v.patch
 }
+
+// Revision returns the revision of a four-part version, or 0 if the Version is nil. For example, for version
+// "1.2.3.4", the revision is 4
+func (v *Version) Revision() int {
+	if v == nil {
+		return 0
+	}
+	return v.revision
+}
+
+// IsFourPart returns true if the version is a four-part version (e.g. "1.2.3.4")
+func (v *Version) IsFourPart() bool {
+	if v == nil {
+		return false
+	}
+	return v.fourPart
+}